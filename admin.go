@@ -0,0 +1,48 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// adminRequest 执行管理接口请求（自动检查登录状态）
+// method: HTTP方法
+// apiPath: 接口路径（如 "/api/admin/storage/list"）
+// query: 查询参数（可为nil）
+// body: 请求体（可为nil）
+// result: 响应数据（可为nil）
+func (c *OpenListAPI) adminRequest(ctx context.Context, method, apiPath string, query url.Values, body, result interface{}) error {
+	// 先检查登录状态
+	if err := c.ensureLogin("调用管理接口"); err != nil {
+		return err
+	}
+
+	reqURL := c.baseURL + apiPath
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	return c.doRequestContext(ctx, &HTTPRequest{
+		Method: method,
+		URL:    reqURL,
+		Body:   body,
+	}, result)
+}
+
+// idQuery 构造 id 查询参数
+func idQuery(id uint) url.Values {
+	return url.Values{"id": {fmt.Sprintf("%d", id)}}
+}
+
+// pageQuery 构造分页查询参数（page<=0 时默认为1，perPage<=0 时不分页）
+func pageQuery(page, perPage int) url.Values {
+	if page <= 0 {
+		page = 1
+	}
+	query := url.Values{"page": {fmt.Sprintf("%d", page)}}
+	if perPage > 0 {
+		query.Set("per_page", fmt.Sprintf("%d", perPage))
+	}
+	return query
+}
@@ -0,0 +1,163 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ArchiveMeta 获取压缩包元信息（注释、是否加密、内部文件树）
+// archivePath: 压缩包远程路径（如 "/releases/v1.0.0.zip"）
+// archivePass: 压缩包密码（未加密时为空）
+// 返回值: 压缩包元信息，错误信息
+func (c *OpenListAPI) ArchiveMeta(ctx context.Context, archivePath, archivePass string) (*ArchiveMeta, error) {
+	// 先检查登录状态
+	if err := c.ensureLogin("获取压缩包信息"); err != nil {
+		return nil, err
+	}
+
+	// 构造请求体
+	metaReq := ArchiveMetaRequest{
+		Path:        archivePath,
+		Password:    c.pathPassword(archivePath),
+		ArchivePass: archivePass,
+	}
+
+	// 执行请求
+	meta := &ArchiveMeta{}
+	if err := c.doRequestContext(ctx, &HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/archive/meta", c.baseURL),
+		Body:   metaReq,
+	}, meta); err != nil {
+		return nil, fmt.Errorf("获取压缩包信息失败: %w", passwordError(archivePath, metaReq.Password, err))
+	}
+
+	return meta, nil
+}
+
+// ArchiveList 列出压缩包内指定目录的文件
+// archivePath: 压缩包远程路径
+// innerPath: 压缩包内目录（默认 "/"）
+// archivePass: 压缩包密码（未加密时为空）
+// page: 页码（默认 1）
+// perPage: 每页条数（0表示不分页）
+// 返回值: 目录列表响应，错误信息
+func (c *OpenListAPI) ArchiveList(ctx context.Context, archivePath, innerPath, archivePass string, page, perPage int) (*ListResponse, error) {
+	// 先检查登录状态
+	if err := c.ensureLogin("列出压缩包内容"); err != nil {
+		return nil, err
+	}
+
+	// 处理默认参数
+	if innerPath == "" {
+		innerPath = "/"
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	// 构造请求体
+	listReq := ArchiveListRequest{
+		Path:        archivePath,
+		Password:    c.pathPassword(archivePath),
+		Page:        page,
+		PerPage:     perPage,
+		ArchivePass: archivePass,
+		InnerPath:   innerPath,
+	}
+
+	// 执行请求
+	listResp := &ListResponse{}
+	if err := c.doRequestContext(ctx, &HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/archive/list", c.baseURL),
+		Body:   listReq,
+	}, listResp); err != nil {
+		return nil, fmt.Errorf("列出压缩包内容失败: %w", passwordError(archivePath, listReq.Password, err))
+	}
+
+	return listResp, nil
+}
+
+// OpenArchiveFile 以流的形式读取压缩包内的单个文件（无需下载整个压缩包）
+// archivePath: 压缩包远程路径
+// innerPath: 压缩包内文件路径（如 "/README.md"）
+// archivePass: 压缩包密码（未加密时为空）
+// 返回值: 文件内容流（调用方负责关闭），错误信息
+func (c *OpenListAPI) OpenArchiveFile(ctx context.Context, archivePath, innerPath, archivePass string) (io.ReadCloser, error) {
+	// 获取压缩包签名
+	meta, err := c.ArchiveMeta(ctx, archivePath, archivePass)
+	if err != nil {
+		return nil, err
+	}
+
+	// 构造下载地址（/ad/<压缩包路径>?inner=...&pass=...&sign=...）
+	query := url.Values{}
+	query.Set("inner", innerPath)
+	if archivePass != "" {
+		query.Set("pass", archivePass)
+	}
+	if meta.Sign != "" {
+		query.Set("sign", meta.Sign)
+	}
+	reqURL := fmt.Sprintf("%s/ad%s?%s", c.baseURL, encodeRemotePath(archivePath), query.Encode())
+
+	// 发送请求（令牌过期时重新登录并重试）
+	var resp *http.Response
+	err = c.withRelogin(Operation{Name: "archive.download", Path: archivePath}, true, func(op Operation, token string) error {
+		// 创建HTTP请求
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return fmt.Errorf("创建下载请求失败: %w", err)
+		}
+		req.Header.Set("Authorization", token)
+
+		// 数据流由调用方读取，不限制总超时，由 ctx 控制
+		resp, err = c.send(c.transferClient(), req, op)
+		if err != nil {
+			return fmt.Errorf("发送下载请求失败: %w", err)
+		}
+
+		// 检查响应状态
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return statusError("读取压缩包内文件失败", resp.StatusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 按下载带宽限速
+	return &throttledReadCloser{Reader: throttle(ctx, resp.Body, c.downloadBandwidth), Closer: resp.Body}, nil
+}
+
+// Decompress 提交服务端解压任务
+// 返回值: 创建的解压任务列表（可通过 Tasks().WaitTask 等待完成），错误信息
+func (c *OpenListAPI) Decompress(ctx context.Context, decompressReq DecompressRequest) ([]TaskInfo, error) {
+	// 先检查登录状态
+	if err := c.ensureLogin("提交解压任务"); err != nil {
+		return nil, err
+	}
+
+	// 处理默认参数
+	if decompressReq.InnerPath == "" {
+		decompressReq.InnerPath = "/"
+	}
+
+	// 执行请求
+	decompressResp := &DecompressResponse{}
+	if err := c.doRequestContext(ctx, &HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/archive/decompress", c.baseURL),
+		Body:   decompressReq,
+	}, decompressResp); err != nil {
+		return nil, fmt.Errorf("提交解压任务失败: %w", err)
+	}
+
+	return decompressResp.Tasks, nil
+}
@@ -0,0 +1,122 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// 带宽限制的令牌桶最大容量（字节）
+const maxBandwidthBurst = 256 * 1024
+
+// WithBandwidthLimits 设置上传、下载带宽限制（字节/秒，<=0 表示不限制）
+// 限制由同一客户端的所有并发传输共享，运行时可通过 SetUploadLimit、SetDownloadLimit 调整
+func WithBandwidthLimits(uploadBytesPerSec, downloadBytesPerSec int64) Option {
+	return func(c *OpenListAPI) {
+		c.SetUploadLimit(uploadBytesPerSec)
+		c.SetDownloadLimit(downloadBytesPerSec)
+	}
+}
+
+// SetUploadLimit 调整上传带宽限制（字节/秒，<=0 表示不限制），对进行中的传输立即生效
+func (c *OpenListAPI) SetUploadLimit(bytesPerSec int64) {
+	setBandwidth(c.uploadBandwidth, bytesPerSec)
+}
+
+// SetDownloadLimit 调整下载带宽限制（字节/秒，<=0 表示不限制），对进行中的传输立即生效
+func (c *OpenListAPI) SetDownloadLimit(bytesPerSec int64) {
+	setBandwidth(c.downloadBandwidth, bytesPerSec)
+}
+
+// WithContext 设置传输的上下文，用于取消传输或限制总耗时
+// 传输请求不受 HTTP 客户端 Timeout 限制（限速或大文件时耗时可能远超该值），需要超时请通过上下文设置
+func WithContext(ctx context.Context) TransferOption {
+	return func(o *transferOptions) {
+		if ctx != nil {
+			o.ctx = ctx
+		}
+	}
+}
+
+// transferClient 传输使用的HTTP客户端（不设置总超时，由请求上下文控制）
+func (c *OpenListAPI) transferClient() *http.Client {
+	client := *c.httpClient
+	client.Timeout = 0
+	return &client
+}
+
+// newBandwidth 创建不限速的带宽令牌桶
+func newBandwidth() *rate.Limiter {
+	return rate.NewLimiter(rate.Inf, 1)
+}
+
+// setBandwidth 调整带宽令牌桶速率（容量为1秒流量，最大 maxBandwidthBurst）
+func setBandwidth(limiter *rate.Limiter, bytesPerSec int64) {
+	if bytesPerSec <= 0 {
+		limiter.SetLimit(rate.Inf)
+		return
+	}
+	limiter.SetBurst(int(min(bytesPerSec, maxBandwidthBurst)))
+	limiter.SetLimit(rate.Limit(bytesPerSec))
+}
+
+// throttledReader 按带宽令牌桶限速的Reader
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+// throttle 包装Reader，按带宽令牌桶限速（可与 ProgressReader 组合使用）
+func throttle(ctx context.Context, reader io.Reader, limiter *rate.Limiter) io.Reader {
+	return &throttledReader{ctx: ctx, reader: reader, limiter: limiter}
+}
+
+// Read 实现io.Reader接口（每次最多读取一个令牌桶容量，读取后等待相应令牌）
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if t.limiter.Limit() == rate.Inf {
+		return t.reader.Read(p)
+	}
+
+	if burst := t.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := t.reader.Read(p)
+	if n > 0 {
+		if waitErr := waitBandwidth(t.ctx, t.limiter, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// waitBandwidth 等待 n 字节的令牌（运行时调整容量后按新容量分批等待）
+func waitBandwidth(ctx context.Context, limiter *rate.Limiter, n int) error {
+	for n > 0 {
+		if limiter.Limit() == rate.Inf {
+			return nil
+		}
+		chunk := min(n, max(limiter.Burst(), 1))
+		if err := limiter.WaitN(ctx, chunk); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if _, ok := ctx.Deadline(); ok {
+				// 等待时间超过截止时间时令牌桶提前返回，按超时处理
+				return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+			}
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// throttledReadCloser 限速的 ReadCloser（关闭时关闭原始数据流）
+type throttledReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,326 @@
+package openlist
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigBundleVersion 当前配置导出格式版本
+const ConfigBundleVersion = 1
+
+// excludedSettingKeys 不参与导出/导入的设置项（各服务器独立的密钥）
+var excludedSettingKeys = map[string]bool{
+	"token": true,
+}
+
+// ConfigBundle 服务端配置快照（设置、存储、元信息、用户）
+type ConfigBundle struct {
+	Version    int           `json:"version"`     // 格式版本
+	ExportedAt time.Time     `json:"exported_at"` // 导出时间
+	Settings   []SettingItem `json:"settings"`    // 站点设置
+	Storages   []Storage     `json:"storages"`    // 存储（按挂载路径匹配）
+	Metas      []Meta        `json:"metas"`       // 元信息（按路径匹配）
+	Users      []User        `json:"users"`       // 用户（按用户名匹配，不含密码和内置的管理员/访客）
+}
+
+// ImportReport 导入结果统计
+type ImportReport struct {
+	SettingsSaved   int // 保存的设置项数量
+	StoragesCreated int // 新建的存储数量
+	StoragesUpdated int // 更新的存储数量
+	StoragesSkipped int // 无变化的存储数量
+	MetasCreated    int // 新建的元信息数量
+	MetasUpdated    int // 更新的元信息数量
+	MetasSkipped    int // 无变化的元信息数量
+	UsersCreated    int // 新建的用户数量
+	UsersUpdated    int // 更新的用户数量
+	UsersSkipped    int // 无变化或内置角色（管理员/访客）而跳过的用户数量
+
+	// GeneratedPasswords 新建用户时自动生成的随机密码（用户名 → 密码），
+	// 仅包含未通过 WithUserPasswords 指定密码的新用户
+	GeneratedPasswords map[string]string
+}
+
+// ImportOption 导入选项
+type ImportOption func(*importOptions)
+
+type importOptions struct {
+	userPasswords map[string]string
+}
+
+// WithUserPasswords 指定导入用户的密码（用户名 → 密码）
+// 新建用户使用指定密码，未指定时生成随机密码并记录在 ImportReport.GeneratedPasswords；
+// 已存在的用户仅在指定了密码时修改密码
+func WithUserPasswords(passwords map[string]string) ImportOption {
+	return func(o *importOptions) {
+		o.userPasswords = passwords
+	}
+}
+
+// WriteJSON 将配置快照写入 w（带缩进的JSON）
+func (b *ConfigBundle) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(b); err != nil {
+		return fmt.Errorf("写入配置快照失败: %w", err)
+	}
+	return nil
+}
+
+// WriteYAML 将配置快照写入 w（YAML，字段名与JSON一致）
+func (b *ConfigBundle) WriteYAML(w io.Writer) error {
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("写入配置快照失败: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("写入配置快照失败: %w", err)
+	}
+	return nil
+}
+
+// ReadConfigBundle 从 r 读取配置快照（JSON 或 YAML）
+func ReadConfigBundle(r io.Reader) (*ConfigBundle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置快照失败: %w", err)
+	}
+	// YAML 是 JSON 的超集，统一转换为JSON后解析
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置快照失败: %w", err)
+	}
+
+	bundle := &ConfigBundle{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(bundle); err != nil {
+		return nil, fmt.Errorf("读取配置快照失败: %w", err)
+	}
+	if bundle.Version > ConfigBundleVersion {
+		return nil, fmt.Errorf("不支持的配置快照版本: %d（当前支持 %d）", bundle.Version, ConfigBundleVersion)
+	}
+	return bundle, nil
+}
+
+// ExportConfig 导出服务端配置（设置、存储、元信息、用户），用于在其他环境中复现
+func (c *OpenListAPI) ExportConfig(ctx context.Context) (*ConfigBundle, error) {
+	bundle := &ConfigBundle{
+		Version:    ConfigBundleVersion,
+		ExportedAt: time.Now(),
+	}
+
+	settings, err := c.Settings().List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, setting := range settings {
+		if exportableSetting(setting) {
+			bundle.Settings = append(bundle.Settings, setting)
+		}
+	}
+
+	storages, err := c.Storages().List(ctx, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Storages = storages.Content
+
+	metas, err := c.Metas().List(ctx, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Metas = metas.Content
+
+	users, err := c.Users().List(ctx, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users.Content {
+		if builtinUser(user) {
+			continue // 管理员和访客由各服务器自行初始化
+		}
+		user.Password = ""
+		bundle.Users = append(bundle.Users, user)
+	}
+
+	return bundle, nil
+}
+
+// ImportConfig 将配置快照应用到当前服务端（幂等：按挂载路径/路径/用户名匹配，已存在则更新，无变化则跳过）
+// 快照不含用户密码：新建用户使用 WithUserPasswords 指定的密码，未指定时生成随机密码并通过
+// ImportReport.GeneratedPasswords 返回；管理员和访客用户不参与导入
+func (c *OpenListAPI) ImportConfig(ctx context.Context, bundle *ConfigBundle, opts ...ImportOption) (*ImportReport, error) {
+	if bundle.Version > ConfigBundleVersion {
+		return nil, fmt.Errorf("不支持的配置快照版本: %d（当前支持 %d）", bundle.Version, ConfigBundleVersion)
+	}
+	options := &importOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	report := &ImportReport{}
+
+	// 设置项整体保存（保存本身是幂等的）
+	var settings []SettingItem
+	for _, setting := range bundle.Settings {
+		if exportableSetting(setting) {
+			settings = append(settings, setting)
+		}
+	}
+	if len(settings) > 0 {
+		if err := c.Settings().Save(ctx, settings); err != nil {
+			return report, err
+		}
+		report.SettingsSaved = len(settings)
+	}
+
+	if err := c.importStorages(ctx, bundle.Storages, report); err != nil {
+		return report, err
+	}
+	if err := c.importMetas(ctx, bundle.Metas, report); err != nil {
+		return report, err
+	}
+	if err := c.importUsers(ctx, bundle.Users, options.userPasswords, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// exportableSetting 判断设置项是否参与导出/导入
+func exportableSetting(setting SettingItem) bool {
+	return !excludedSettingKeys[setting.Key] &&
+		setting.Flag != SettingFlagReadonly && setting.Flag != SettingFlagDeprecated
+}
+
+// builtinUser 判断是否为服务端内置用户（管理员、访客），这类用户不参与导出/导入
+func builtinUser(user User) bool {
+	return user.Role == RoleAdmin || user.Role == RoleGuest
+}
+
+// importStorages 按挂载路径导入存储
+func (c *OpenListAPI) importStorages(ctx context.Context, storages []Storage, report *ImportReport) error {
+	existingResp, err := c.Storages().List(ctx, 1, 0)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]Storage, len(existingResp.Content))
+	for _, storage := range existingResp.Content {
+		existing[storage.MountPath] = storage
+	}
+
+	for _, storage := range storages {
+		current, ok := existing[storage.MountPath]
+		storage.ID = current.ID
+		storage.Status = ""
+		storage.Modified = time.Time{}
+		if !ok {
+			if _, err := c.Storages().Create(ctx, &storage); err != nil {
+				return fmt.Errorf("导入存储 %s 失败: %w", storage.MountPath, err)
+			}
+			report.StoragesCreated++
+			continue
+		}
+
+		current.Status = ""
+		current.Modified = time.Time{}
+		if reflect.DeepEqual(current, storage) {
+			report.StoragesSkipped++
+			continue
+		}
+		if err := c.Storages().Update(ctx, &storage); err != nil {
+			return fmt.Errorf("导入存储 %s 失败: %w", storage.MountPath, err)
+		}
+		report.StoragesUpdated++
+	}
+	return nil
+}
+
+// importMetas 按路径导入元信息
+func (c *OpenListAPI) importMetas(ctx context.Context, metas []Meta, report *ImportReport) error {
+	existingResp, err := c.Metas().List(ctx, 1, 0)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]Meta, len(existingResp.Content))
+	for _, meta := range existingResp.Content {
+		existing[meta.Path] = meta
+	}
+
+	for _, meta := range metas {
+		current, ok := existing[meta.Path]
+		meta.ID = current.ID
+		if !ok {
+			if err := c.Metas().Create(ctx, &meta); err != nil {
+				return fmt.Errorf("导入元信息 %s 失败: %w", meta.Path, err)
+			}
+			report.MetasCreated++
+			continue
+		}
+
+		if current == meta {
+			report.MetasSkipped++
+			continue
+		}
+		if err := c.Metas().Update(ctx, &meta); err != nil {
+			return fmt.Errorf("导入元信息 %s 失败: %w", meta.Path, err)
+		}
+		report.MetasUpdated++
+	}
+	return nil
+}
+
+// importUsers 按用户名导入用户（跳过管理员和访客；未指定密码时新用户使用随机密码，已有用户保持原密码）
+func (c *OpenListAPI) importUsers(ctx context.Context, users []User, passwords map[string]string, report *ImportReport) error {
+	existingResp, err := c.Users().List(ctx, 1, 0)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]User, len(existingResp.Content))
+	for _, user := range existingResp.Content {
+		existing[user.Username] = user
+	}
+
+	for _, user := range users {
+		current, ok := existing[user.Username]
+		if builtinUser(user) || (ok && builtinUser(current)) {
+			report.UsersSkipped++
+			continue
+		}
+
+		user.ID = current.ID
+		user.Password = passwords[user.Username]
+		if !ok {
+			if user.Password == "" {
+				user.Password = rand.Text()
+				if report.GeneratedPasswords == nil {
+					report.GeneratedPasswords = map[string]string{}
+				}
+				report.GeneratedPasswords[user.Username] = user.Password
+			}
+			if err := c.Users().Create(ctx, &user); err != nil {
+				return fmt.Errorf("导入用户 %s 失败: %w", user.Username, err)
+			}
+			report.UsersCreated++
+			continue
+		}
+
+		current.Password = ""
+		if user.Password == "" && current == user {
+			report.UsersSkipped++
+			continue
+		}
+		if err := c.Users().Update(ctx, &user); err != nil {
+			return fmt.Errorf("导入用户 %s 失败: %w", user.Username, err)
+		}
+		report.UsersUpdated++
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package openlist
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache 元数据缓存（可自定义实现，如接入 Redis）
+// 客户端缓存列目录和获取文件信息的结果，并在通过同一客户端新建目录、上传、删除、重命名、移动、复制后
+// 使用 DeletePrefix 清除受影响的目录及其子路径
+type Cache interface {
+	Get(key string) (any, bool) // 获取缓存值（不存在或已过期时返回false）
+	Set(key string, value any)  // 设置缓存值
+	DeletePrefix(prefix string) // 删除指定前缀的所有缓存
+}
+
+// WithCache 启用元数据缓存（如 NewMemoryCache(30*time.Second, 10000)）
+// 缓存条目不含可能过期的下载地址和签名（Raw_url、Sign），命中缓存时这两个字段为空；
+// DownloadFile 始终向服务端获取最新的下载地址
+func WithCache(cache Cache) Option {
+	return func(c *OpenListAPI) {
+		c.cache = cache
+	}
+}
+
+// 缓存键以路径开头，便于按路径前缀清除（键格式: "<路径>|list|<页码>|<每页条数>|<密码摘要>"、"<路径>|get|<密码摘要>"）
+
+// listCacheKey 列目录缓存键
+func listCacheKey(p string, page, perPage int, password string) string {
+	return fmt.Sprintf("%s|list|%d|%d|%s", path.Clean(p), page, perPage, passwordDigest(password))
+}
+
+// getCacheKey 文件信息缓存键
+func getCacheKey(p, password string) string {
+	return path.Clean(p) + "|get|" + passwordDigest(password)
+}
+
+// passwordDigest 访问密码摘要（不同密码的结果分别缓存，且不在键中暴露明文）
+func passwordDigest(password string) string {
+	if password == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheableFileInfo 复制文件信息（深拷贝哈希信息，去掉可能过期的下载地址和签名）
+func cacheableFileInfo(fileInfo FileInfo) FileInfo {
+	fileInfo.Raw_url = ""
+	fileInfo.Sign = ""
+	fileInfo.Hash_info = maps.Clone(fileInfo.Hash_info)
+	return fileInfo
+}
+
+// cloneList 深拷贝列目录结果
+func cloneList(listResp *ListResponse) *ListResponse {
+	copied := *listResp
+	copied.Content = make([]FileInfo, len(listResp.Content))
+	for i, item := range listResp.Content {
+		copied.Content[i] = cacheableFileInfo(item)
+	}
+	return &copied
+}
+
+// cachedList 读取列目录缓存（返回副本，调用方修改不影响缓存）
+func (c *OpenListAPI) cachedList(key string) (*ListResponse, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	value, ok := c.cache.Get(key)
+	listResp, isList := value.(*ListResponse)
+	if !ok || !isList {
+		return nil, false
+	}
+	return cloneList(listResp), true
+}
+
+// cachedFileInfo 读取文件信息缓存（返回副本）
+func (c *OpenListAPI) cachedFileInfo(key string) (*FileInfo, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	value, ok := c.cache.Get(key)
+	fileInfo, isInfo := value.(*FileInfo)
+	if !ok || !isInfo {
+		return nil, false
+	}
+	copied := cacheableFileInfo(*fileInfo)
+	return &copied, true
+}
+
+// storeList 写入列目录缓存（保存副本）
+func (c *OpenListAPI) storeList(key string, listResp *ListResponse) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.Set(key, cloneList(listResp))
+}
+
+// storeFileInfo 写入文件信息缓存（保存副本）
+func (c *OpenListAPI) storeFileInfo(key string, fileInfo *FileInfo) {
+	if c.cache == nil {
+		return
+	}
+	copied := cacheableFileInfo(*fileInfo)
+	c.cache.Set(key, &copied)
+}
+
+// invalidate 清除路径及其子路径、所在目录的缓存
+func (c *OpenListAPI) invalidate(paths ...string) {
+	if c.cache == nil {
+		return
+	}
+
+	// 合并重复的前缀（批量操作时所在目录相同）
+	prefixes := map[string]bool{}
+	for _, p := range paths {
+		p = path.Clean("/" + p)
+		prefixes[parentDir(p)+"|"] = true               // 所在目录的列表和信息
+		prefixes[p+"|"] = true                          // 路径本身
+		prefixes[strings.TrimSuffix(p, "/")+"/"] = true // 子路径
+	}
+	for prefix := range prefixes {
+		c.cache.DeletePrefix(prefix)
+	}
+}
+
+// invalidateNames 清除目录下指定名称的缓存
+func (c *OpenListAPI) invalidateNames(dir string, names []string) {
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = path.Join(dir, name)
+	}
+	c.invalidate(paths...)
+}
+
+// parentDir 返回路径所在目录
+func parentDir(p string) string {
+	return path.Dir(path.Clean("/" + p))
+}
+
+// MemoryCache 内存缓存（带过期时间和容量上限，超出容量时淘汰最久未使用的条目）
+// 按前缀删除时在有序键索引上二分查找，只遍历匹配的条目
+type MemoryCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // 最近使用的在前
+	keys    []string   // 有序键索引（用于按前缀删除）
+}
+
+// memoryEntry 内存缓存条目
+type memoryEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+// NewMemoryCache 创建内存缓存
+// ttl: 过期时间（<=0 表示不过期）
+// maxEntries: 最大条目数（<=0 表示不限制）
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// Get 实现 Cache 接口
+func (m *MemoryCache) Get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		m.remove(elem)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set 实现 Cache 接口
+func (m *MemoryCache) Set(key string, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expires time.Time
+	if m.ttl > 0 {
+		expires = time.Now().Add(m.ttl)
+	}
+
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value, entry.expires = value, expires
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	i, _ := slices.BinarySearch(m.keys, key)
+	m.keys = slices.Insert(m.keys, i, key)
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+// DeletePrefix 实现 Cache 接口
+func (m *MemoryCache) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	start, _ := slices.BinarySearch(m.keys, prefix)
+	end := start
+	for end < len(m.keys) && strings.HasPrefix(m.keys[end], prefix) {
+		m.order.Remove(m.entries[m.keys[end]])
+		delete(m.entries, m.keys[end])
+		end++
+	}
+	m.keys = slices.Delete(m.keys, start, end)
+}
+
+// Len 返回当前条目数（含未清理的过期条目）
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// remove 删除条目（调用方需持有锁）
+func (m *MemoryCache) remove(elem *list.Element) {
+	key := elem.Value.(*memoryEntry).key
+	m.order.Remove(elem)
+	delete(m.entries, key)
+	if i, ok := slices.BinarySearch(m.keys, key); ok {
+		m.keys = slices.Delete(m.keys, i, i+1)
+	}
+}
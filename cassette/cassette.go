@@ -0,0 +1,367 @@
+// Package cassette 提供HTTP录制/回放传输层，用于针对真实 OpenList 版本的回归测试
+//
+// 录制模式下请求会转发到真实服务，并将请求/响应对（已脱敏）保存到磁带文件；
+// 回放模式下从磁带文件中按顺序匹配请求并返回录制的响应，未匹配的请求直接报错。
+//
+//	recorder, err := cassette.New("testdata/list.json", cassette.ModeReplay, nil)
+//	api := openlist.NewOpenListAPI(baseURL, "admin", "password", "",
+//		openlist.WithHTTPClient(recorder.Client()))
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode 磁带工作模式
+type Mode int
+
+const (
+	ModeRecord Mode = iota // 录制：转发到真实服务并保存交互
+	ModeReplay             // 回放：从磁带返回响应，不访问网络
+)
+
+// Redacted 脱敏后的占位值
+const Redacted = "REDACTED"
+
+// ErrNoMatch 回放时没有匹配的录制请求
+var ErrNoMatch = errors.New("cassette: 没有匹配的录制请求")
+
+// redactedHeaders 需要脱敏的请求头
+var redactedHeaders = []string{"Authorization", "Password"}
+
+// redactedFields 需要脱敏的JSON字段（请求体与响应体）
+var redactedFields = map[string]bool{
+	"password":     true,
+	"token":        true,
+	"archive_pass": true,
+	"pwd":          true,
+	"secret":       true,
+	"otp_code":     true,
+	"sign":         true,
+}
+
+// redactedQueryParams 需要脱敏的查询参数（请求地址与JSON中的URL，如 raw_url）
+var redactedQueryParams = []string{"sign", "pass"}
+
+// Request 录制的请求
+type Request struct {
+	Method     string      `json:"method"`                // HTTP方法
+	Path       string      `json:"path"`                  // 请求路径（不含主机）
+	Query      string      `json:"query,omitempty"`       // 查询字符串
+	Header     http.Header `json:"header,omitempty"`      // 请求头（已脱敏）
+	Body       string      `json:"body,omitempty"`        // 请求体（已脱敏）
+	BodyBase64 bool        `json:"body_base64,omitempty"` // 请求体是否为base64编码
+}
+
+// Response 录制的响应
+type Response struct {
+	StatusCode int         `json:"status_code"`           // HTTP状态码
+	Header     http.Header `json:"header,omitempty"`      // 响应头
+	Body       string      `json:"body,omitempty"`        // 响应体（已脱敏）
+	BodyBase64 bool        `json:"body_base64,omitempty"` // 响应体是否为base64编码
+}
+
+// Interaction 一次请求/响应交互
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recorder 录制/回放传输层（实现 http.RoundTripper）
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New 创建录制/回放传输层
+// path: 磁带文件路径
+// mode: 工作模式（回放模式会立即加载磁带文件）
+// transport: 录制模式下实际发送请求的传输层（为nil时使用 http.DefaultTransport）
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: path, transport: transport}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取磁带文件失败: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("解析磁带文件失败: %w", err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+// Client 返回使用该传输层的HTTP客户端（可通过 openlist.WithHTTPClient 注入）
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions 返回已录制（或已加载）的交互
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Unused 返回回放模式下尚未被匹配的交互数量（用于断言请求序列完全一致）
+func (r *Recorder) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, used := range r.used {
+		if !used {
+			count++
+		}
+	}
+	return count
+}
+
+// Save 将录制的交互写入磁带文件（仅录制模式有效）
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("序列化磁带失败: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("创建磁带目录失败: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		return fmt.Errorf("写入磁带文件失败: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+// record 转发请求并保存交互
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	response.Body, response.BodyBase64 = encodeBody(redactJSON(body))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay 按顺序查找第一个未使用且匹配的交互
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyBase64)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoMatch, recorded.Method, recorded.Path)
+}
+
+// matches 判断两个请求是否匹配（方法、路径、查询参数、脱敏后的请求体）
+func matches(a, b Request) bool {
+	return a.Method == b.Method && a.Path == b.Path && a.Query == b.Query &&
+		a.Body == b.Body && a.BodyBase64 == b.BodyBase64
+}
+
+// recordRequest 读取并脱敏请求（请求体读取后会被还原，可继续发送）
+func recordRequest(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  redactQuery(req.URL.RawQuery),
+		Header: req.Header.Clone(),
+	}
+	for _, key := range redactedHeaders {
+		if recorded.Header.Get(key) != "" {
+			recorded.Header.Set(key, Redacted)
+		}
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	// multipart 边界每次随机生成，统一替换后再比较
+	if _, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && params["boundary"] != "" {
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("BOUNDARY"))
+		recorded.Header.Set("Content-Type", strings.ReplaceAll(req.Header.Get("Content-Type"), params["boundary"], "BOUNDARY"))
+	}
+	recorded.Body, recorded.BodyBase64 = encodeBody(redactJSON(body))
+	return recorded, nil
+}
+
+// redactQuery 脱敏查询字符串中的敏感参数（不含敏感参数时原样返回）
+func redactQuery(rawQuery string) string {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	changed := false
+	for _, key := range redactedQueryParams {
+		if query.Get(key) != "" {
+			query.Set(key, Redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawQuery
+	}
+	return query.Encode()
+}
+
+// redactURL 脱敏URL字符串中的敏感查询参数（非URL原样返回）
+func redactURL(s string) string {
+	if !strings.Contains(s, "?") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return s
+	}
+	u.RawQuery = redactQuery(u.RawQuery)
+	return u.String()
+}
+
+// redactJSON 脱敏JSON中的敏感字段，并规范化字段顺序（非JSON内容原样返回）
+// 数字按原文保留（UseNumber），避免大整数（如 int64 大小）经 float64 丢失精度
+func redactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) != nil || decoder.More() {
+		return body
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactValue 递归脱敏
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if redactedFields[strings.ToLower(key)] {
+				if s, ok := item.(string); ok && s == "" {
+					continue
+				}
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	case string:
+		return redactURL(v)
+	}
+	return value
+}
+
+// encodeBody 编码内容（文本原样保存，二进制使用base64）
+func encodeBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+// decodeBody 解码内容
+func decodeBody(body string, isBase64 bool) ([]byte, error) {
+	if !isBase64 {
+		return []byte(body), nil
+	}
+	data, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("解码磁带内容失败: %w", err)
+	}
+	return data, nil
+}
@@ -2,20 +2,25 @@ package openlist
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/creasty/defaults"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // OpenListAPI OpenList 服务客户端
@@ -26,13 +31,32 @@ type OpenListAPI struct {
 	proxy          string       // 代理地址（如 http://127.0.0.1:8080）
 	token          string       // 登录令牌
 	httpClient     *http.Client // HTTP客户端（带代理配置）
-	mu             sync.RWMutex // 并发安全锁（保护token、proxy状态）
+	mu             sync.RWMutex // 并发安全锁（保护token、login、proxy状态）
+	login          *loginCall   // 进行中的登录请求
 	proxyTested    bool         // 代理是否已测试
 	proxyAvailable bool         // 代理是否可用
+
+	passwords   PasswordProvider // 受保护目录的密码提供者（可选）
+	signSecret  string           // 服务端签名密钥（可选，用于客户端生成签名链接）
+	middlewares []Middleware     // 请求中间件
+	logger      *slog.Logger     // 结构化日志（默认丢弃）
+
+	tracerProvider trace.TracerProvider // 链路追踪提供者（可选）
+	meterProvider  metric.MeterProvider // 指标提供者（可选）
+	telemetry      *telemetry           // 链路追踪与指标
+	stats          *Stats               // 运行统计
+	limits         *rateLimiter         // 限流（可选）
+	cache          Cache                // 元数据缓存（可选）
+
+	uploadBandwidth   *rate.Limiter // 上传带宽（所有上传共享）
+	downloadBandwidth *rate.Limiter // 下载带宽（所有下载共享）
+
+	taskPollInterval time.Duration // WaitTask 轮询间隔（可选）
 }
 
 // NewOpenListAPI 创建OpenListAPI客户端实例
-func NewOpenListAPI(baseURL, username, password, proxy string) *OpenListAPI {
+// opts: 客户端配置选项（可选，如 WithPasswordProvider）
+func NewOpenListAPI(baseURL, username, password, proxy string, opts ...Option) *OpenListAPI {
 	// 处理baseURL末尾的斜杠（确保统一格式）
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
@@ -45,10 +69,24 @@ func NewOpenListAPI(baseURL, username, password, proxy string) *OpenListAPI {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		logger: slog.New(slog.DiscardHandler),
+		stats:  &Stats{},
+
+		uploadBandwidth:   newBandwidth(),
+		downloadBandwidth: newBandwidth(),
 	}
 
-	// 若配置了代理，初始化代理客户端
-	if proxy != "" {
+	// 应用配置选项
+	for _, opt := range opts {
+		if opt != nil {
+			opt(client)
+		}
+	}
+
+	client.initTelemetry()
+
+	// 若配置了代理，初始化代理客户端（自定义Transport优先）
+	if proxy != "" && client.httpClient.Transport == nil {
 		client.initProxyClient()
 	}
 
@@ -60,7 +98,9 @@ func (c *OpenListAPI) initProxyClient() {
 	// 解析代理URL
 	proxyURL, err := url.Parse(c.proxy)
 	if err != nil {
-		// 直接返回，不打印日志
+		// 解析失败时不使用代理
+		c.logger.Warn("代理地址解析失败，不使用代理", slog.String("proxy", redactURL(c.proxy)))
+		c.stats.proxyFallbacks.Add(1)
 		return
 	}
 
@@ -111,6 +151,7 @@ func (c *OpenListAPI) TestProxy() bool {
 	// 尝试TCP连接代理（5秒超时）
 	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
 	if err != nil {
+		c.logger.Warn("代理不可用", slog.String("proxy", redactURL(c.proxy)), slog.String("error", err.Error()))
 		c.proxyTested = true
 		c.proxyAvailable = false
 		return false
@@ -132,15 +173,30 @@ func (c *OpenListAPI) ResetProxyStatus() {
 	c.proxyAvailable = false
 }
 
+// loginCall 进行中的登录请求（并发调用 Login 时共享结果）
+type loginCall struct {
+	done chan struct{} // 登录结束后关闭
+	err  error         // 登录错误
+}
+
 // Login 登录OpenList服务，获取访问令牌
+// 发送登录请求期间不持有锁（限流等待、网络请求不会阻塞读取令牌），并发调用只发送一次登录请求
 func (c *OpenListAPI) Login() (bool, error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	// 若已存在有效令牌，直接返回成功
 	if c.token != "" {
+		c.mu.Unlock()
 		return true, nil
 	}
+	// 已有登录请求进行中，等待其结果
+	if call := c.login; call != nil {
+		c.mu.Unlock()
+		<-call.done
+		return call.err == nil, call.err
+	}
+	call := &loginCall{done: make(chan struct{})}
+	c.login = call
+	c.mu.Unlock()
 
 	// 构造登录请求体
 	loginReq := LoginRequest{
@@ -150,50 +206,91 @@ func (c *OpenListAPI) Login() (bool, error) {
 
 	// 执行请求
 	loginResp := &LoginResponse{}
-	if err := c.doRequest(&HTTPRequest{
+	err := c.doRequest(&HTTPRequest{
 		Method: "POST",
 		URL:    fmt.Sprintf("%s/api/auth/login", c.baseURL),
 		Body:   loginReq,
-	}, loginResp); err != nil {
-		return false, fmt.Errorf("登录失败: %w", err)
-	}
+	}, loginResp)
 
 	// 登录成功，保存令牌
-	c.token = loginResp.Token
-	return true, nil
+	c.mu.Lock()
+	if err != nil {
+		call.err = fmt.Errorf("登录失败: %w", err)
+	} else {
+		c.token = loginResp.Token
+		c.stats.logins.Add(1)
+	}
+	c.login = nil
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.err == nil, call.err
 }
 
 // UploadFile 上传文件到OpenList服务
 // filePaths: 本地文件路径
 // remotePath: 远程存储目录（如 "/docs"）
-// 返回值: 远程文件完整路径（如 "/docs/test.txt"），错误信息
-func (c *OpenListAPI) UploadFile(filePath, remotePath string) (string, error) {
+// opts: 传输选项（可选，如 WithVerifyHash、WithConflictPolicy）
+// 返回值: 远程文件完整路径（如 "/docs/test.txt"，自动重命名时为新路径），错误信息
+// 注意：ConflictSkip 跳过上传时同样返回远程路径且无错误，需要区分时请使用 UploadFileWithResult
+func (c *OpenListAPI) UploadFile(filePath, remotePath string, opts ...TransferOption) (string, error) {
+	result, err := c.UploadFileWithResult(filePath, remotePath, opts...)
+	if result == nil {
+		return "", err
+	}
+	return result.Path, err
+}
+
+// UploadFileWithResult 上传文件到OpenList服务（表单上传），返回包含冲突处理结果的上传结果
+// filePaths: 本地文件路径
+// remotePath: 远程存储目录（如 "/docs"）
+// opts: 传输选项（可选，如 WithVerifyHash、WithConflictPolicy）
+// 返回值: 上传结果（Outcome 为 UploadSkipped 时未上传），错误信息
+func (c *OpenListAPI) UploadFileWithResult(filePath, remotePath string, opts ...TransferOption) (*UploadResult, error) {
+	options := newTransferOptions(opts)
+
 	// 先检查登录状态
 	if ok, err := c.Login(); !ok {
 		if err != nil {
-			return "", fmt.Errorf("登录失败: %w", err)
+			return nil, fmt.Errorf("登录失败: %w", err)
 		}
-		return "", fmt.Errorf("登录失败，无法执行文件上传")
+		return nil, fmt.Errorf("登录失败，无法执行文件上传")
 	}
 
 	// 验证本地文件是否存在
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("本地文件不存在: %s", filePath)
+		return nil, fmt.Errorf("本地文件不存在: %s", filePath)
 	}
 
 	// 打开本地文件
 	file, err := os.Open(filePath)
 	if err != nil {
-		return "", fmt.Errorf("打开本地文件失败: %w", err)
+		return nil, fmt.Errorf("打开本地文件失败: %w", err)
 	}
 	defer file.Close()
 
 	// 构造远程完整路径（处理重复斜杠，如 "/docs//test.txt" → "/docs/test.txt"）
 	fileName := filepath.Base(filePath)
 	fullRemotePath := strings.ReplaceAll(fmt.Sprintf("%s/%s", remotePath, fileName), "//", "/")
-	// URL编码远程路径（保留斜杠，避免转义）
-	encodedPath := url.QueryEscape(fullRemotePath)
-	encodedPath = strings.ReplaceAll(encodedPath, "%2F", "/") // 恢复斜杠
+
+	// 按冲突策略确定上传路径
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("获取本地文件信息失败: %w", err)
+	}
+	resolution, err := c.resolveConflict(fullRemotePath, stat.Size(), options, func(types []HashType) (HashInfo, error) {
+		return precomputeHashes(file, types)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resolution.outcome == UploadSkipped {
+		return &UploadResult{Path: fullRemotePath, Size: stat.Size(), Outcome: resolution.outcome}, nil
+	}
+	fullRemotePath = resolution.path
+	fileName = path.Base(fullRemotePath)
+	// URL编码远程路径（逐段编码，保留斜杠；服务端按 PathUnescape 解码）
+	encodedPath := encodeRemotePath(fullRemotePath)
 
 	// 构造上传请求URL
 	reqURL := fmt.Sprintf("%s/api/fs/form", c.baseURL)
@@ -204,63 +301,80 @@ func (c *OpenListAPI) UploadFile(filePath, remotePath string) (string, error) {
 	// 添加文件字段（字段名"file"需与服务端一致）
 	formFile, err := writer.CreateFormFile("file", fileName)
 	if err != nil {
-		return "", fmt.Errorf("创建表单文件失败: %w", err)
+		return nil, fmt.Errorf("创建表单文件失败: %w", err)
 	}
-	// 复制文件内容到表单
-	if _, err := io.Copy(formFile, file); err != nil {
-		return "", fmt.Errorf("复制文件到表单失败: %w", err)
+	// 复制文件内容到表单（需要校验哈希时同步计算）
+	var hasher *multiHasher
+	var formWriter io.Writer = formFile
+	if options.verifyHash {
+		hasher = newMultiHasher(options.verifyTypes)
+		formWriter = io.MultiWriter(formFile, hasher)
+	}
+	if _, err := io.Copy(formWriter, file); err != nil {
+		return nil, fmt.Errorf("复制文件到表单失败: %w", err)
 	}
 	// 关闭writer，确保边界符正确写入
 	if err := writer.Close(); err != nil {
-		return "", fmt.Errorf("关闭表单写入器失败: %w", err)
+		return nil, fmt.Errorf("关闭表单写入器失败: %w", err)
 	}
 
-	// 构造HTTP请求
-	req, err := http.NewRequest("PUT", reqURL, body)
-	if err != nil {
-		return "", fmt.Errorf("创建上传请求失败: %w", err)
-	}
-	// 设置请求头（Authorization、Content-Type、file-path）
-	req.Header.Set("Authorization", c.getToken())
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("file-path", encodedPath)
-	// 延长上传超时（大文件上传可能需要更长时间，此处设5分钟）
-	req.Close = true
-	client := *c.httpClient
-	client.Timeout = 5 * time.Minute // 覆盖默认超时
-
-	// 发送上传请求
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("发送上传请求失败: %w", err)
-	}
-	defer resp.Body.Close()
+	// 上传不限制总超时（限速或大文件时耗时较长），由 WithContext 控制
+	client := c.transferClient()
 
-	// 读取响应体
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("读取上传响应失败: %w", err)
-	}
+	// 发送上传请求（表单已在内存中，令牌过期时可重放）
+	err = c.withRelogin(Operation{Name: "upload", Path: fullRemotePath}, true, func(op Operation, token string) error {
+		// 构造HTTP请求（按上传带宽限速）
+		req, err := http.NewRequestWithContext(options.ctx, "PUT", reqURL, throttle(options.ctx, bytes.NewReader(body.Bytes()), c.uploadBandwidth))
+		if err != nil {
+			return fmt.Errorf("创建上传请求失败: %w", err)
+		}
+		req.ContentLength = int64(body.Len())
+		// 设置请求头（Authorization、Content-Type、file-path）
+		req.Header.Set("Authorization", token)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("file-path", encodedPath)
+		if options.conflictSet {
+			req.Header.Set("Overwrite", fmt.Sprintf("%t", resolution.overwrite))
+		}
+		req.Close = true
 
-	// 解析上传响应
-	var apiResp APIResponse
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return "", fmt.Errorf("解析上传响应失败，响应体: %s, 原因: %w", string(respBody), err)
+		resp, err := c.send(client, req, op)
+		if err != nil {
+			return fmt.Errorf("发送上传请求失败: %w", err)
+		}
+		return readUploadResponse(resp)
+	})
+	if err != nil {
+		return nil, err
 	}
+	c.invalidate(fullRemotePath)
 
-	// 检查上传结果
-	if resp.StatusCode != http.StatusOK || apiResp.Code != 200 {
-		return "", fmt.Errorf("上传失败，HTTP状态码: %d, 错误码: %d, 消息: %s",
-			resp.StatusCode, apiResp.Code, apiResp.Message)
+	// 校验服务端大小和哈希（校验失败时文件已上传，仍返回上传结果）
+	result := &UploadResult{Path: fullRemotePath, Size: stat.Size(), Outcome: resolution.outcome}
+	if hasher != nil {
+		remoteInfo, err := c.getFileInfo(fullRemotePath, false)
+		if err != nil {
+			return result, fmt.Errorf("获取上传后文件信息失败: %w", err)
+		}
+		if err := verifyTransfer(fullRemotePath, result.Size, hasher.Sum(), remoteInfo); err != nil {
+			return result, err
+		}
 	}
 
-	return fullRemotePath, nil
+	return result, nil
 }
 
 // GetFileInfo 获取文件信息（含下载地址）
 // filePath: 远程文件路径（如 "/docs/test.txt"）
 // 返回值: 文件信息结构体，错误信息
+// 注意：启用缓存（WithCache）时，命中缓存的结果不含 Raw_url 和 Sign
 func (c *OpenListAPI) GetFileInfo(filePath string) (*FileInfo, error) {
+	return c.getFileInfo(filePath, true)
+}
+
+// getFileInfo 获取文件信息
+// useCache: 是否读取缓存（解析下载地址时需要最新结果，不读取缓存）
+func (c *OpenListAPI) getFileInfo(filePath string, useCache bool) (*FileInfo, error) {
 	// 先检查登录状态
 	if ok, err := c.Login(); !ok {
 		if err != nil {
@@ -269,10 +383,19 @@ func (c *OpenListAPI) GetFileInfo(filePath string) (*FileInfo, error) {
 		return nil, fmt.Errorf("登录失败，无法获取文件信息")
 	}
 
+	// 优先读取缓存
+	password := c.pathPassword(filePath)
+	cacheKey := getCacheKey(filePath, password)
+	if useCache {
+		if fileInfo, ok := c.cachedFileInfo(cacheKey); ok {
+			return fileInfo, nil
+		}
+	}
+
 	// 构造请求体
 	fileInfoReq := FileInfoRequest{
 		Path:     filePath,
-		Password: "",
+		Password: password,
 	}
 
 	// 执行请求
@@ -282,8 +405,9 @@ func (c *OpenListAPI) GetFileInfo(filePath string) (*FileInfo, error) {
 		URL:    fmt.Sprintf("%s/api/fs/get", c.baseURL),
 		Body:   fileInfoReq,
 	}, fileInfo); err != nil {
-		return nil, fmt.Errorf("获取文件信息失败: %w", err)
+		return nil, fmt.Errorf("获取文件信息失败: %w", passwordError(filePath, fileInfoReq.Password, err))
 	}
+	c.storeFileInfo(cacheKey, fileInfo)
 
 	return fileInfo, nil
 }
@@ -293,6 +417,16 @@ func (c *OpenListAPI) GetFileInfo(filePath string) (*FileInfo, error) {
 // parentPath: 搜索父目录（默认 "/"）
 // 返回值: 搜索结果列表，错误信息
 func (c *OpenListAPI) SearchFiles(keyword, parentPath string) (*SearchResult, error) {
+	return c.Search(context.Background(), SearchRequest{
+		Parent:   parentPath,
+		Keywords: keyword,
+	})
+}
+
+// Search 按完整条件搜索文件（范围、分页）
+// searchReq: 搜索条件（Parent 默认 "/"，Page 默认 1，Per_page 默认 50，Password 为空时使用密码提供者）
+// 返回值: 搜索结果列表，错误信息
+func (c *OpenListAPI) Search(ctx context.Context, searchReq SearchRequest) (*SearchResult, error) {
 	// 先检查登录状态
 	if ok, err := c.Login(); !ok {
 		if err != nil {
@@ -302,24 +436,22 @@ func (c *OpenListAPI) SearchFiles(keyword, parentPath string) (*SearchResult, er
 	}
 
 	// 处理默认父目录（为空时设为 "/"）
-	if parentPath == "" {
-		parentPath = "/"
+	if searchReq.Parent == "" {
+		searchReq.Parent = "/"
 	}
-
-	// 构造请求体
-	searchReq := SearchRequest{
-		Parent:   parentPath,
-		Keywords: keyword,
+	if searchReq.Password == "" {
+		searchReq.Password = c.pathPassword(searchReq.Parent)
 	}
 	defaults.Set(&searchReq)
+
 	// 执行请求
 	var searchResults SearchResult
-	if err := c.doRequest(&HTTPRequest{
+	if err := c.doRequestContext(ctx, &HTTPRequest{
 		Method: "POST",
 		URL:    fmt.Sprintf("%s/api/fs/search", c.baseURL),
 		Body:   searchReq,
 	}, &searchResults); err != nil {
-		return nil, fmt.Errorf("搜索文件失败: %w", err)
+		return nil, fmt.Errorf("搜索文件失败: %w", passwordError(searchReq.Parent, searchReq.Password, err))
 	}
 
 	return &searchResults, nil
@@ -348,10 +480,19 @@ func (c *OpenListAPI) ListFiles(path string, page, perPage int, refresh bool) (*
 		page = 1
 	}
 
+	// 未要求强制刷新时优先读取缓存
+	password := c.pathPassword(path)
+	cacheKey := listCacheKey(path, page, perPage, password)
+	if !refresh {
+		if listResp, ok := c.cachedList(cacheKey); ok {
+			return listResp, nil
+		}
+	}
+
 	// 构造请求体
 	listReq := ListRequest{
 		Path:     path,
-		Password: "",
+		Password: password,
 		Page:     page,
 		PerPage:  perPage,
 		Refresh:  refresh,
@@ -364,8 +505,9 @@ func (c *OpenListAPI) ListFiles(path string, page, perPage int, refresh bool) (*
 		URL:    fmt.Sprintf("%s/api/fs/list", c.baseURL),
 		Body:   listReq,
 	}, listResp); err != nil {
-		return nil, fmt.Errorf("列出目录失败: %w", err)
+		return nil, fmt.Errorf("列出目录失败: %w", passwordError(path, listReq.Password, err))
 	}
+	c.storeList(cacheKey, listResp)
 
 	return listResp, nil
 }
@@ -381,8 +523,11 @@ func (c *OpenListAPI) getToken() string {
 // remotePath: 远程文件路径（如 "/docs/test.txt"）
 // localPath: 本地保存路径
 // progressFunc: 进度回调函数（可选）
+// opts: 传输选项（可选，如 WithVerifyHash）
 // 返回值: 错误信息
-func (c *OpenListAPI) DownloadFile(remotePath, localPath string, progressFunc ProgressFunc) error {
+func (c *OpenListAPI) DownloadFile(remotePath, localPath string, progressFunc ProgressFunc, opts ...TransferOption) error {
+	options := newTransferOptions(opts)
+
 	// 先检查登录状态
 	if ok, err := c.Login(); !ok {
 		if err != nil {
@@ -391,35 +536,44 @@ func (c *OpenListAPI) DownloadFile(remotePath, localPath string, progressFunc Pr
 		return fmt.Errorf("登录失败，无法执行文件下载")
 	}
 
-	// 获取文件信息（包含下载地址）
-	fileInfo, err := c.GetFileInfo(remotePath)
+	// 获取文件信息（包含下载地址，不读取缓存，避免使用已过期的签名地址）
+	fileInfo, err := c.getFileInfo(remotePath, false)
 	if err != nil {
 		return fmt.Errorf("获取文件信息失败: %w", err)
 	}
 
-	// 创建HTTP请求
-	req, err := http.NewRequest("GET", fileInfo.Raw_url, nil)
-	if err != nil {
-		return fmt.Errorf("创建下载请求失败: %w", err)
-	}
+	// 发送请求（令牌过期时重新登录并重试）
+	var resp *http.Response
+	err = c.withRelogin(Operation{Name: "download", Path: remotePath}, true, func(op Operation, token string) error {
+		// 创建HTTP请求
+		req, err := http.NewRequestWithContext(options.ctx, "GET", fileInfo.Raw_url, nil)
+		if err != nil {
+			return fmt.Errorf("创建下载请求失败: %w", err)
+		}
 
-	// 设置认证头
-	if c.getToken() != "" {
-		req.Header.Set("Authorization", c.getToken())
-	}
+		// 设置认证头
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
 
-	// 发送请求
-	resp, err := c.httpClient.Do(req)
+		// 下载不限制总超时（限速或大文件时耗时较长），由 WithContext 控制
+		resp, err = c.send(c.transferClient(), req, op)
+		if err != nil {
+			return fmt.Errorf("发送下载请求失败: %w", err)
+		}
+
+		// 检查响应状态
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return statusError("下载失败", resp.StatusCode)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("发送下载请求失败: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	// 检查响应状态
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("下载失败，HTTP状态码: %d", resp.StatusCode)
-	}
-
 	// 创建本地文件
 	localFile, err := os.Create(localPath)
 	if err != nil {
@@ -436,23 +590,36 @@ func (c *OpenListAPI) DownloadFile(remotePath, localPath string, progressFunc Pr
 		}
 	}
 
-	// 创建带进度回调的Reader
-	var reader io.Reader = resp.Body
+	// 创建带进度回调的Reader（按下载带宽限速）
+	reader := throttle(options.ctx, resp.Body, c.downloadBandwidth)
 	if progressFunc != nil && fileSize > 0 {
 		reader = &ProgressReader{
-			reader:       resp.Body,
+			reader:       reader,
 			total:        fileSize,
 			downloaded:   0,
 			progressFunc: progressFunc,
 		}
 	}
 
-	// 复制数据到本地文件
-	_, err = io.Copy(localFile, reader)
+	// 复制数据到本地文件（需要校验哈希时同步计算）
+	var hasher *multiHasher
+	var writer io.Writer = localFile
+	if options.verifyHash {
+		hasher = newMultiHasher(options.verifyTypes)
+		writer = io.MultiWriter(localFile, hasher)
+	}
+	written, err := io.Copy(writer, reader)
 	if err != nil {
 		return fmt.Errorf("保存文件失败: %w", err)
 	}
 
+	// 校验服务端大小和哈希
+	if hasher != nil {
+		if err := verifyTransfer(remotePath, written, hasher.Sum(), fileInfo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -504,6 +671,7 @@ func (c *OpenListAPI) Remove(dir string, names []string) error {
 	}, nil); err != nil {
 		return fmt.Errorf("删除文件或文件夹失败: %w", err)
 	}
+	c.invalidateNames(dir, names)
 
 	return nil
 }
@@ -533,6 +701,7 @@ func (c *OpenListAPI) Mkdir(path string) error {
 	}, nil); err != nil {
 		return fmt.Errorf("创建文件夹失败: %w", err)
 	}
+	c.invalidate(path)
 
 	return nil
 }
@@ -591,6 +760,7 @@ func (c *OpenListAPI) Mkdirs(path string) error {
 					return fmt.Errorf("创建文件夹失败 (路径: %s): %w", currentPath, err)
 				}
 			}
+			c.invalidate(currentPath)
 		}
 	}
 
@@ -0,0 +1,152 @@
+package openlist
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrFileExists 远程文件已存在（冲突策略为 ConflictFail 时返回）
+var ErrFileExists = errors.New("远程文件已存在")
+
+// ConflictPolicy 上传冲突策略（远程已存在同名文件时的处理方式）
+type ConflictPolicy int
+
+const (
+	ConflictOverwrite ConflictPolicy = iota // 覆盖已存在的文件
+	ConflictFail                            // 已存在时返回 ErrFileExists
+	ConflictSkip                            // 大小（及可比对的哈希）一致时跳过，否则覆盖
+	ConflictRename                          // 自动重命名为 "name (1).ext" 形式
+)
+
+// maxRenameAttempts 自动重命名的最大尝试次数
+const maxRenameAttempts = 1000
+
+// UploadOutcome 上传结果类型
+type UploadOutcome string
+
+const (
+	UploadUploaded    UploadOutcome = "uploaded"    // 已上传（未指定冲突策略，未检查远程文件）
+	UploadCreated     UploadOutcome = "created"     // 远程不存在，已新建
+	UploadOverwritten UploadOutcome = "overwritten" // 远程已存在，已覆盖
+	UploadSkipped     UploadOutcome = "skipped"     // 远程已存在且内容一致，已跳过
+	UploadRenamed     UploadOutcome = "renamed"     // 远程已存在，已重命名后上传
+)
+
+// WithConflictPolicy 设置上传冲突策略（上传前向服务端检查远程文件，不读取元数据缓存）
+func WithConflictPolicy(policy ConflictPolicy) TransferOption {
+	return func(o *transferOptions) {
+		o.conflictSet = true
+		o.conflict = policy
+	}
+}
+
+// conflictResolution 冲突处理结果
+type conflictResolution struct {
+	path      string        // 最终上传路径
+	outcome   UploadOutcome // 处理结果
+	overwrite bool          // 是否允许服务端覆盖
+}
+
+// resolveConflict 根据冲突策略确定上传路径
+// remoteFilePath: 期望的远程文件完整路径
+// size: 本地数据长度
+// localHashes: 按需计算本地哈希（仅在 ConflictSkip 且服务端提供哈希时调用）
+func (c *OpenListAPI) resolveConflict(remoteFilePath string, size int64, options *transferOptions,
+	localHashes func(types []HashType) (HashInfo, error)) (*conflictResolution, error) {
+	// 未指定冲突策略时保持服务端默认行为
+	if !options.conflictSet {
+		return &conflictResolution{path: remoteFilePath, outcome: UploadUploaded, overwrite: true}, nil
+	}
+
+	// 是否覆盖取决于远程文件的当前状态，不读取缓存
+	existing, err := c.getFileInfo(remoteFilePath, false)
+	if err != nil {
+		if isNotFoundError(err) {
+			return &conflictResolution{path: remoteFilePath, outcome: UploadCreated}, nil
+		}
+		return nil, fmt.Errorf("检查远程文件失败: %w", err)
+	}
+
+	switch options.conflict {
+	case ConflictFail:
+		return nil, fmt.Errorf("%w: %s", ErrFileExists, remoteFilePath)
+
+	case ConflictSkip:
+		identical, err := isIdentical(existing, size, localHashes)
+		if err != nil {
+			return nil, err
+		}
+		if identical {
+			return &conflictResolution{path: remoteFilePath, outcome: UploadSkipped}, nil
+		}
+		return &conflictResolution{path: remoteFilePath, outcome: UploadOverwritten, overwrite: true}, nil
+
+	case ConflictRename:
+		renamed, err := c.nextFreePath(remoteFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return &conflictResolution{path: renamed, outcome: UploadRenamed}, nil
+
+	default:
+		return &conflictResolution{path: remoteFilePath, outcome: UploadOverwritten, overwrite: true}, nil
+	}
+}
+
+// isIdentical 判断远程文件与本地数据是否一致（先比较大小，再比较双方都有的哈希）
+func isIdentical(existing *FileInfo, size int64, localHashes func(types []HashType) (HashInfo, error)) (bool, error) {
+	if existing.IsDir || existing.Size != size {
+		return false, nil
+	}
+
+	// 找出服务端提供且可本地计算的哈希类型
+	remote := existing.Hashes()
+	var types []HashType
+	for t := range remote {
+		if newHash(t) != nil && remote.Get(t) != "" {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 || localHashes == nil {
+		return true, nil
+	}
+
+	local, err := localHashes(types)
+	if err != nil {
+		return false, err
+	}
+	_, err = compareHashes("", local, remote)
+	return err == nil, nil
+}
+
+// nextFreePath 查找可用的重命名路径（如 "/docs/a (1).txt"）
+func (c *OpenListAPI) nextFreePath(remoteFilePath string) (string, error) {
+	dir, name := path.Split(remoteFilePath)
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 1; i <= maxRenameAttempts; i++ {
+		candidate := fmt.Sprintf("%s%s (%d)%s", dir, base, i, ext)
+		if _, err := c.getFileInfo(candidate, false); err != nil {
+			if isNotFoundError(err) {
+				return candidate, nil
+			}
+			return "", fmt.Errorf("检查远程文件失败: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("自动重命名失败，已尝试 %d 次: %s", maxRenameAttempts, remoteFilePath)
+}
+
+// isNotFoundError 判断错误是否为远程对象不存在
+// OpenList 获取文件信息失败时统一返回业务状态码500，需结合服务端的 "object not found" 消息判断
+// （避免将 "storage not found" 等其他错误误判为文件不存在）
+func isNotFoundError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 500 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "object not found")
+}
@@ -2,34 +2,101 @@ package openlist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 )
 
 // doRequest 执行通用HTTP请求
 func (c *OpenListAPI) doRequest(req *HTTPRequest, result interface{}) error {
+	return c.doRequestContext(context.Background(), req, result)
+}
+
+// doRequestContext 执行通用HTTP请求（支持取消和超时控制）
+// 令牌过期（业务状态码401）时自动重新登录并重试一次
+func (c *OpenListAPI) doRequestContext(ctx context.Context, req *HTTPRequest, result interface{}) error {
 	// 序列化请求体
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if req.Body != nil {
-
-		bodyBytes, err := json.Marshal(req.Body)
+		var err error
+		bodyBytes, err = json.Marshal(req.Body)
 		if err != nil {
 			return fmt.Errorf("序列化请求体失败: %w", err)
 		}
-		bodyReader = bytes.NewBuffer(bodyBytes)
+	}
+
+	op := operationFor(req.URL, bodyBytes)
+	return c.withRelogin(op, true, func(op Operation, token string) error {
+		return c.doRequestOnce(ctx, req, bodyBytes, op, token, result)
+	})
+}
+
+// errUnauthorized HTTP状态码401（令牌无效或过期）
+var errUnauthorized = errors.New("未授权")
+
+// statusError 构造HTTP状态码错误（401 时包装 errUnauthorized，以便重新登录后重试）
+// action: 操作描述（如 "下载失败"）
+func statusError(action string, statusCode int) error {
+	if statusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%s，HTTP状态码: %d: %w", action, statusCode, errUnauthorized)
+	}
+	return fmt.Errorf("%s，HTTP状态码: %d", action, statusCode)
+}
+
+// isUnauthorized 判断错误是否表示令牌无效或过期（业务状态码或HTTP状态码为401）
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusUnauthorized
+	}
+	return errors.Is(err, errUnauthorized)
+}
+
+// withRelogin 执行一次请求，令牌过期时重新登录并重试一次（doRequest、上传、下载共用）
+// op: 逻辑操作（重试时 Attempt 递增）
+// replayable: 请求能否重放（请求体已被消费且无法回退时为 false，不重试）
+// attempt: 使用给定令牌发送一次请求
+func (c *OpenListAPI) withRelogin(op Operation, replayable bool, attempt func(op Operation, token string) error) error {
+	if op.Attempt <= 0 {
+		op.Attempt = 1
+	}
+	for {
+		// 登录请求本身不携带令牌
+		token := ""
+		if op.Name != loginOperation {
+			token = c.getToken()
+		}
+		err := attempt(op, token)
+
+		if replayable && op.Attempt == 1 && op.Name != loginOperation && isUnauthorized(err) && c.relogin(token) {
+			op.Attempt++
+			c.logger.Warn("重试请求", slog.String("op", op.Name), slog.String("path", op.Path), slog.Int("attempt", op.Attempt))
+			continue
+		}
+		return err
+	}
+}
+
+// doRequestOnce 使用给定令牌发送一次请求并解析响应
+func (c *OpenListAPI) doRequestOnce(ctx context.Context, req *HTTPRequest, bodyBytes []byte, op Operation, token string, result interface{}) error {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
 	// 创建HTTP请求
-	httpReq, err := http.NewRequest(req.Method, req.URL, bodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("创建HTTP请求失败: %w", err)
 	}
 
 	// 设置请求头
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", c.token)
+	httpReq.Header.Set("Authorization", token)
 
 	// 设置自定义请求头
 	for key, value := range req.Headers {
@@ -37,7 +104,7 @@ func (c *OpenListAPI) doRequest(req *HTTPRequest, result interface{}) error {
 	}
 
 	// 发送请求
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send(c.httpClient, httpReq, op)
 	if err != nil {
 		return fmt.Errorf("发送HTTP请求失败: %w", err)
 	}
@@ -51,7 +118,11 @@ func (c *OpenListAPI) doRequest(req *HTTPRequest, result interface{}) error {
 
 	// 检查HTTP状态码
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP请求失败，状态码: %d, 响应体: %s", resp.StatusCode, string(respBody))
+		err := fmt.Errorf("HTTP请求失败，状态码: %d, 响应体: %s", resp.StatusCode, string(respBody))
+		if resp.StatusCode == http.StatusUnauthorized {
+			err = fmt.Errorf("%w: %w", err, errUnauthorized)
+		}
+		return err
 	}
 
 	// 解析响应
@@ -64,8 +135,31 @@ func (c *OpenListAPI) doRequest(req *HTTPRequest, result interface{}) error {
 
 	// 检查业务状态码
 	if apiResp.Code != 200 {
-		return fmt.Errorf("API调用失败，错误码: %d, 消息: %s", apiResp.Code, apiResp.Message)
+		return &APIError{Code: apiResp.Code, Message: apiResp.Message}
 	}
 
 	return nil
 }
+
+// relogin 令牌过期后重新登录
+// staleToken: 过期的令牌（其他请求已刷新令牌时直接复用新令牌）
+// 返回值: 是否已获得新令牌
+func (c *OpenListAPI) relogin(staleToken string) bool {
+	if c.username == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	if c.token == staleToken {
+		c.token = ""
+	}
+	c.mu.Unlock()
+
+	c.logger.Warn("令牌已过期，重新登录", slog.String("username", c.username))
+	c.stats.relogins.Add(1)
+	ok, err := c.Login()
+	if err != nil {
+		c.logger.Warn("重新登录失败", slog.String("error", err.Error()))
+	}
+	return ok && err == nil
+}
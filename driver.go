@@ -0,0 +1,175 @@
+package openlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 驱动配置项类型
+const (
+	DriverItemString = "string" // 字符串
+	DriverItemNumber = "number" // 数字
+	DriverItemBool   = "bool"   // 布尔
+	DriverItemSelect = "select" // 单选（可选值见 Options）
+	DriverItemText   = "text"   // 多行文本
+)
+
+// DriverService 驱动信息查询，对应 /api/admin/driver/*
+type DriverService struct {
+	api *OpenListAPI
+}
+
+// Drivers 获取驱动信息查询客户端（需要管理员权限）
+func (c *OpenListAPI) Drivers() DriverCatalog {
+	return &DriverService{api: c}
+}
+
+// List 列出所有驱动信息（驱动名称 → 驱动信息）
+func (s *DriverService) List(ctx context.Context) (map[string]DriverInfo, error) {
+	drivers := map[string]DriverInfo{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/driver/list", nil, nil, &drivers); err != nil {
+		return nil, fmt.Errorf("列出驱动失败: %w", err)
+	}
+	return drivers, nil
+}
+
+// Names 列出所有驱动名称
+func (s *DriverService) Names(ctx context.Context) ([]string, error) {
+	var names []string
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/driver/names", nil, nil, &names); err != nil {
+		return nil, fmt.Errorf("列出驱动名称失败: %w", err)
+	}
+	return names, nil
+}
+
+// Info 获取单个驱动信息
+func (s *DriverService) Info(ctx context.Context, driver string) (*DriverInfo, error) {
+	info := &DriverInfo{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/driver/info", url.Values{"driver": {driver}}, nil, info); err != nil {
+		return nil, fmt.Errorf("获取驱动信息失败: %w", err)
+	}
+	return info, nil
+}
+
+// ValidateStorage 按服务端驱动定义校验存储的附加配置
+func (s *DriverService) ValidateStorage(ctx context.Context, storage *Storage) error {
+	info, err := s.Info(ctx, storage.Driver)
+	if err != nil {
+		return err
+	}
+	if info.Config.Name == "" {
+		info.Config.Name = storage.Driver
+	}
+	addition, err := storage.AdditionMap()
+	if err != nil {
+		return err
+	}
+	return info.ValidateAddition(addition)
+}
+
+// ValidateAddition 在客户端校验附加配置（未知字段、必填项、类型、可选值）
+// 返回值: 校验通过返回nil，否则返回 *AdditionValidationError
+func (d *DriverInfo) ValidateAddition(addition map[string]interface{}) error {
+	var issues []string
+	known := make(map[string]bool, len(d.Additional))
+
+	for _, item := range d.Additional {
+		known[item.Name] = true
+		value, ok := addition[item.Name]
+		if !ok || value == nil || value == "" {
+			if item.Required && item.Default == "" {
+				issues = append(issues, fmt.Sprintf("缺少必填项 %s", item.Name))
+			}
+			continue
+		}
+		if issue := item.check(value); issue != "" {
+			issues = append(issues, issue)
+		}
+	}
+
+	// 未知字段（多为拼写错误，服务端会静默忽略）
+	var unknown []string
+	for name := range addition {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		issues = append(issues, fmt.Sprintf("未知配置项 %s", name))
+	}
+
+	if len(issues) > 0 {
+		return &AdditionValidationError{Driver: d.Config.Name, Issues: issues}
+	}
+	return nil
+}
+
+// ApplyDefaults 为缺失的配置项填充驱动默认值（按配置项类型转换）
+func (d *DriverInfo) ApplyDefaults(addition map[string]interface{}) {
+	for _, item := range d.Additional {
+		if _, ok := addition[item.Name]; ok || item.Default == "" {
+			continue
+		}
+		switch item.Type {
+		case DriverItemNumber:
+			if n, err := strconv.ParseFloat(item.Default, 64); err == nil {
+				addition[item.Name] = n
+				continue
+			}
+		case DriverItemBool:
+			if b, err := strconv.ParseBool(item.Default); err == nil {
+				addition[item.Name] = b
+				continue
+			}
+		}
+		addition[item.Name] = item.Default
+	}
+}
+
+// check 校验单个配置项的值，返回问题描述（无问题时返回空字符串）
+func (item *DriverItem) check(value interface{}) string {
+	switch item.Type {
+	case DriverItemNumber:
+		switch v := value.(type) {
+		case float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+			return ""
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return ""
+			}
+		}
+		return fmt.Sprintf("配置项 %s 应为数字，实际为 %v", item.Name, value)
+
+	case DriverItemBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("配置项 %s 应为布尔值，实际为 %v", item.Name, value)
+		}
+
+	case DriverItemSelect:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("配置项 %s 应为字符串，实际为 %v", item.Name, value)
+		}
+		if item.Options == "" {
+			return ""
+		}
+		for _, option := range strings.Split(item.Options, ",") {
+			if strings.TrimSpace(option) == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("配置项 %s 的值 %q 不在可选范围 [%s] 内", item.Name, v, item.Options)
+
+	default:
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("配置项 %s 应为字符串，实际为 %v", item.Name, value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package openlist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError 服务端返回的业务错误（业务状态码非200）
+type APIError struct {
+	Code    int    // 业务状态码
+	Message string // 错误信息
+}
+
+// Error 实现error接口
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API调用失败，错误码: %d, 消息: %s", e.Code, e.Message)
+}
+
+// ChecksumMismatchError 传输完成后哈希校验不一致
+type ChecksumMismatchError struct {
+	Path     string   // 远程文件路径
+	Type     HashType // 哈希类型
+	Expected string   // 服务端哈希值
+	Actual   string   // 本地计算的哈希值
+}
+
+// Error 实现error接口
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("文件哈希校验失败 (路径: %s, 类型: %s, 期望: %s, 实际: %s)",
+		e.Path, e.Type, e.Expected, e.Actual)
+}
+
+// SizeMismatchError 传输完成后文件大小与服务端不一致
+type SizeMismatchError struct {
+	Path     string // 远程文件路径
+	Expected int64  // 服务端文件大小
+	Actual   int64  // 本地传输的字节数
+}
+
+// Error 实现error接口
+func (e *SizeMismatchError) Error() string {
+	return fmt.Sprintf("文件大小校验失败 (路径: %s, 期望: %d, 实际: %d)", e.Path, e.Expected, e.Actual)
+}
+
+// TaskFailedError 后台任务以失败或取消状态结束
+type TaskFailedError struct {
+	Task *TaskInfo // 结束时的任务信息
+}
+
+// Error 实现error接口
+func (e *TaskFailedError) Error() string {
+	return fmt.Sprintf("任务未成功完成 (ID: %s, 名称: %s, 状态: %s, 错误: %s)",
+		e.Task.ID, e.Task.Name, e.Task.State, e.Task.Error)
+}
+
+// AdditionValidationError 驱动附加配置校验失败
+type AdditionValidationError struct {
+	Driver string   // 驱动名称
+	Issues []string // 问题列表
+}
+
+// Error 实现error接口
+func (e *AdditionValidationError) Error() string {
+	return fmt.Sprintf("驱动 %s 附加配置校验失败: %s", e.Driver, strings.Join(e.Issues, "; "))
+}
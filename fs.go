@@ -0,0 +1,76 @@
+package openlist
+
+import "fmt"
+
+// Rename 重命名文件或文件夹
+// path: 文件或文件夹路径（如 "/docs/a.txt"）
+// newName: 新名称（仅名称，不含目录，如 "b.txt"）
+// 返回值: 错误信息
+func (c *OpenListAPI) Rename(path, newName string) error {
+	// 先检查登录状态
+	if err := c.ensureLogin("执行重命名"); err != nil {
+		return err
+	}
+
+	// 执行请求
+	if err := c.doRequest(&HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/rename", c.baseURL),
+		Body:   RenameRequest{Path: path, Name: newName},
+	}, nil); err != nil {
+		return fmt.Errorf("重命名失败: %w", err)
+	}
+	c.invalidate(path)
+	c.invalidateNames(parentDir(path), []string{newName})
+
+	return nil
+}
+
+// Move 移动文件或文件夹（跨存储时服务端以后台任务执行）
+// srcDir: 源目录
+// dstDir: 目标目录
+// names: 要移动的文件或文件夹名称列表
+// 返回值: 错误信息
+func (c *OpenListAPI) Move(srcDir, dstDir string, names []string) error {
+	// 先检查登录状态
+	if err := c.ensureLogin("执行移动"); err != nil {
+		return err
+	}
+
+	// 执行请求
+	if err := c.doRequest(&HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/move", c.baseURL),
+		Body:   MoveCopyRequest{SrcDir: srcDir, DstDir: dstDir, Names: names},
+	}, nil); err != nil {
+		return fmt.Errorf("移动失败: %w", err)
+	}
+	c.invalidateNames(srcDir, names)
+	c.invalidateNames(dstDir, names)
+
+	return nil
+}
+
+// Copy 复制文件或文件夹（跨存储时服务端以后台任务执行）
+// srcDir: 源目录
+// dstDir: 目标目录
+// names: 要复制的文件或文件夹名称列表
+// 返回值: 错误信息
+func (c *OpenListAPI) Copy(srcDir, dstDir string, names []string) error {
+	// 先检查登录状态
+	if err := c.ensureLogin("执行复制"); err != nil {
+		return err
+	}
+
+	// 执行请求
+	if err := c.doRequest(&HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/copy", c.baseURL),
+		Body:   MoveCopyRequest{SrcDir: srcDir, DstDir: dstDir, Names: names},
+	}, nil); err != nil {
+		return fmt.Errorf("复制失败: %w", err)
+	}
+	c.invalidateNames(dstDir, names)
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package openlist
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// HashType 哈希类型（与服务端 hash_info 的键一致）
+type HashType string
+
+const (
+	HashMD5    HashType = "md5"    // MD5（百度网盘、阿里云盘OSS等）
+	HashSHA1   HashType = "sha1"   // SHA1（115、阿里云盘 content_hash 等）
+	HashSHA256 HashType = "sha256" // SHA256
+)
+
+// defaultHashTypes 未指定哈希类型时默认计算的类型
+var defaultHashTypes = []HashType{HashMD5, HashSHA1, HashSHA256}
+
+// HashInfo 文件哈希信息（哈希类型 → 十六进制哈希值）
+// 除 md5/sha1/sha256 外，存储驱动自定义的哈希（如迅雷 gcid）也会原样保留
+type HashInfo map[HashType]string
+
+// Get 获取指定类型的哈希值（统一转为小写，不存在时返回空字符串）
+func (h HashInfo) Get(t HashType) string {
+	return strings.ToLower(h[t])
+}
+
+// MD5 获取MD5哈希值
+func (h HashInfo) MD5() string {
+	return h.Get(HashMD5)
+}
+
+// SHA1 获取SHA1哈希值
+func (h HashInfo) SHA1() string {
+	return h.Get(HashSHA1)
+}
+
+// SHA256 获取SHA256哈希值
+func (h HashInfo) SHA256() string {
+	return h.Get(HashSHA256)
+}
+
+// Hashes 获取文件哈希信息
+// 优先使用 hash_info 字段，缺失时解析 hashinfo 字符串
+func (f *FileInfo) Hashes() HashInfo {
+	if len(f.Hash_info) > 0 {
+		return f.Hash_info
+	}
+
+	hashes := HashInfo{}
+	if f.HashInfo == "" || f.HashInfo == "null" {
+		return hashes
+	}
+	// 解析失败时视为没有哈希信息
+	_ = json.Unmarshal([]byte(f.HashInfo), &hashes)
+	return hashes
+}
+
+// newHash 创建指定类型的哈希计算器（不支持本地计算的类型返回nil）
+func newHash(t HashType) hash.Hash {
+	switch t {
+	case HashMD5:
+		return md5.New()
+	case HashSHA1:
+		return sha1.New()
+	case HashSHA256:
+		return sha256.New()
+	default:
+		return nil
+	}
+}
+
+// multiHasher 在数据流经时同时计算多种哈希
+type multiHasher struct {
+	hashes map[HashType]hash.Hash
+	writer io.Writer
+}
+
+// newMultiHasher 创建多哈希计算器（自动忽略不支持本地计算的类型）
+func newMultiHasher(types []HashType) *multiHasher {
+	if len(types) == 0 {
+		types = defaultHashTypes
+	}
+
+	mh := &multiHasher{hashes: make(map[HashType]hash.Hash)}
+	var writers []io.Writer
+	for _, t := range types {
+		if _, ok := mh.hashes[t]; ok {
+			continue
+		}
+		if h := newHash(t); h != nil {
+			mh.hashes[t] = h
+			writers = append(writers, h)
+		}
+	}
+	mh.writer = io.MultiWriter(writers...)
+	return mh
+}
+
+// Write 实现io.Writer接口
+func (mh *multiHasher) Write(p []byte) (int, error) {
+	return mh.writer.Write(p)
+}
+
+// Sum 返回已计算的哈希结果
+func (mh *multiHasher) Sum() HashInfo {
+	result := HashInfo{}
+	for t, h := range mh.hashes {
+		result[t] = hex.EncodeToString(h.Sum(nil))
+	}
+	return result
+}
+
+// ErrHashUnavailable 服务端未提供可与本地比对的哈希（如 Local 存储），传输内容仅校验了大小
+var ErrHashUnavailable = errors.New("服务端未提供可比对的哈希，未能校验文件内容")
+
+// verifyTransfer 校验传输结果：先比对大小，再比对双方都有的哈希类型
+// 大小不一致时返回 *SizeMismatchError，哈希不一致时返回 *ChecksumMismatchError，
+// 没有可比对的哈希类型时返回 ErrHashUnavailable
+func verifyTransfer(path string, size int64, local HashInfo, remote *FileInfo) error {
+	if remote.Size != size {
+		return &SizeMismatchError{Path: path, Expected: remote.Size, Actual: size}
+	}
+	compared, err := compareHashes(path, local, remote.Hashes())
+	if err != nil {
+		return err
+	}
+	if compared == 0 {
+		return fmt.Errorf("%w: %s", ErrHashUnavailable, path)
+	}
+	return nil
+}
+
+// compareHashes 比对本地计算的哈希与服务端哈希（仅比对双方都有的哈希类型）
+// 返回值: 已比对的哈希类型数量，不一致时返回 *ChecksumMismatchError
+func compareHashes(path string, local, remote HashInfo) (int, error) {
+	compared := 0
+	for t, actual := range local {
+		expected := remote.Get(t)
+		if expected == "" {
+			continue
+		}
+		if !strings.EqualFold(expected, actual) {
+			return compared, &ChecksumMismatchError{
+				Path:     path,
+				Type:     t,
+				Expected: expected,
+				Actual:   actual,
+			}
+		}
+		compared++
+	}
+	return compared, nil
+}
+
+// TransferOption 传输选项（用于 UploadFile / PutFile / UploadStream / DownloadFile）
+type TransferOption func(*transferOptions)
+
+// transferOptions 传输选项集合
+type transferOptions struct {
+	verifyHash  bool            // 是否校验哈希
+	verifyTypes []HashType      // 需要校验的哈希类型
+	hashHints   bool            // 是否发送秒传哈希
+	hintTypes   []HashType      // 需要预先计算的哈希类型
+	knownHashes HashInfo        // 调用方提供的哈希
+	conflictSet bool            // 是否指定了冲突策略
+	conflict    ConflictPolicy  // 冲突策略
+	ctx         context.Context // 传输上下文（取消和超时控制）
+}
+
+// newTransferOptions 应用传输选项
+func newTransferOptions(opts []TransferOption) *transferOptions {
+	o := &transferOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithVerifyHash 传输时边读写边计算哈希，完成后与服务端的大小和哈希比对
+// 大小不一致时返回 *SizeMismatchError，哈希不一致时返回 *ChecksumMismatchError；
+// 服务端没有可比对的哈希时返回 ErrHashUnavailable（大小已比对一致，可按需忽略）。
+// 上传校验失败时文件已写入服务端，上传结果仍会返回
+// types: 需要计算的哈希类型（为空时计算 md5、sha1、sha256）
+func WithVerifyHash(types ...HashType) TransferOption {
+	return func(o *transferOptions) {
+		o.verifyHash = true
+		o.verifyTypes = types
+	}
+}
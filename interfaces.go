@@ -0,0 +1,158 @@
+package openlist
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// FS 文件系统操作（列目录、获取信息、搜索、新建、删除、重命名、移动、复制）
+type FS interface {
+	ListFiles(path string, page, perPage int, refresh bool) (*ListResponse, error)
+	GetFileInfo(filePath string) (*FileInfo, error)
+	SearchFiles(keyword, parentPath string) (*SearchResult, error)
+	Search(ctx context.Context, searchReq SearchRequest) (*SearchResult, error)
+	Mkdir(path string) error
+	Mkdirs(path string) error
+	Remove(dir string, names []string) error
+	Rename(path, newName string) error
+	Move(srcDir, dstDir string, names []string) error
+	Copy(srcDir, dstDir string, names []string) error
+}
+
+// Transfer 文件传输（上传、下载、链接生成）
+type Transfer interface {
+	UploadFile(filePath, remotePath string, opts ...TransferOption) (string, error)
+	UploadFileWithResult(filePath, remotePath string, opts ...TransferOption) (*UploadResult, error)
+	PutFile(filePath, remotePath string, opts ...TransferOption) (*UploadResult, error)
+	UploadStream(reader io.Reader, size int64, remoteFilePath string, opts ...TransferOption) (*UploadResult, error)
+	DownloadFile(remotePath, localPath string, progressFunc ProgressFunc, opts ...TransferOption) error
+	DownloadURL(path, sign string) string
+	ProxyURL(path, sign string) string
+}
+
+// StorageAdmin 存储（挂载）管理
+type StorageAdmin interface {
+	List(ctx context.Context, page, perPage int) (*StorageListResponse, error)
+	Get(ctx context.Context, id uint) (*Storage, error)
+	Create(ctx context.Context, storage *Storage) (uint, error)
+	Update(ctx context.Context, storage *Storage) error
+	Delete(ctx context.Context, id uint) error
+	Enable(ctx context.Context, id uint) error
+	Disable(ctx context.Context, id uint) error
+	LoadAll(ctx context.Context) error
+}
+
+// UserAdmin 用户管理
+type UserAdmin interface {
+	List(ctx context.Context, page, perPage int) (*UserListResponse, error)
+	Get(ctx context.Context, id uint) (*User, error)
+	Create(ctx context.Context, user *User) error
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id uint) error
+	Cancel2FA(ctx context.Context, id uint) error
+	DelCache(ctx context.Context, username string) error
+}
+
+// MetaAdmin 路径元信息管理
+type MetaAdmin interface {
+	List(ctx context.Context, page, perPage int) (*MetaListResponse, error)
+	Get(ctx context.Context, id uint) (*Meta, error)
+	Create(ctx context.Context, meta *Meta) error
+	Update(ctx context.Context, meta *Meta) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// SettingAdmin 站点设置管理
+type SettingAdmin interface {
+	List(ctx context.Context, group int) ([]SettingItem, error)
+	Get(ctx context.Context, key string) (*SettingItem, error)
+	Save(ctx context.Context, settings []SettingItem) error
+	Delete(ctx context.Context, key string) error
+	ResetToken(ctx context.Context) (string, error)
+	SetAria2(ctx context.Context, uri, secret string) (string, error)
+	SetQbit(ctx context.Context, webURL, seedtime string) error
+	SetTransmission(ctx context.Context, uri, seedtime string) error
+}
+
+// DriverCatalog 驱动信息查询
+type DriverCatalog interface {
+	List(ctx context.Context) (map[string]DriverInfo, error)
+	Names(ctx context.Context) ([]string, error)
+	Info(ctx context.Context, driver string) (*DriverInfo, error)
+	ValidateStorage(ctx context.Context, storage *Storage) error
+}
+
+// IndexAdmin 搜索索引管理
+type IndexAdmin interface {
+	Build(ctx context.Context, paths []string, maxDepth int) error
+	Update(ctx context.Context, paths []string, maxDepth int) error
+	Stop(ctx context.Context) error
+	Clear(ctx context.Context) error
+	Progress(ctx context.Context) (*IndexProgress, error)
+}
+
+// Admin 管理接口（存储、用户、元信息、设置、驱动、索引）
+type Admin interface {
+	Storages() StorageAdmin
+	Users() UserAdmin
+	Metas() MetaAdmin
+	Settings() SettingAdmin
+	Drivers() DriverCatalog
+	Index() IndexAdmin
+}
+
+// TaskManager 后台任务管理
+type TaskManager interface {
+	List(ctx context.Context, taskType TaskType, done bool) ([]TaskInfo, error)
+	Get(ctx context.Context, taskType TaskType, taskID string) (*TaskInfo, error)
+	Cancel(ctx context.Context, taskType TaskType, taskID string) error
+	Delete(ctx context.Context, taskType TaskType, taskID string) error
+	Retry(ctx context.Context, taskType TaskType, taskID string) error
+	RetryFailed(ctx context.Context, taskType TaskType) error
+	ClearDone(ctx context.Context, taskType TaskType) error
+	ClearSucceeded(ctx context.Context, taskType TaskType) error
+	WaitTask(ctx context.Context, taskType TaskType, taskID string) (*TaskInfo, error)
+}
+
+// ShareManager 分享链接管理
+type ShareManager interface {
+	List(ctx context.Context, page, perPage int) (*ShareListResponse, error)
+	Get(ctx context.Context, id string) (*Share, error)
+	Create(ctx context.Context, share *Share) (*Share, error)
+	Update(ctx context.Context, share *Share) (*Share, error)
+	Delete(ctx context.Context, id string) error
+	Enable(ctx context.Context, id string) error
+	Disable(ctx context.Context, id string) error
+	URL(id string) string
+}
+
+// Client OpenList 客户端完整接口，供调用方依赖注入模拟实现或装饰器（缓存、指标、限流等）
+type Client interface {
+	FS
+	Transfer
+	Admin
+	Login() (bool, error)
+	Tasks() TaskManager
+	Shares() ShareManager
+	AddOfflineDownload(ctx context.Context, urls []string, destPath string, tool OfflineTool, deletePolicy DeletePolicy) ([]TaskInfo, error)
+	ArchiveMeta(ctx context.Context, archivePath, archivePass string) (*ArchiveMeta, error)
+	ArchiveList(ctx context.Context, archivePath, innerPath, archivePass string, page, perPage int) (*ListResponse, error)
+	OpenArchiveFile(ctx context.Context, archivePath, innerPath, archivePass string) (io.ReadCloser, error)
+	Decompress(ctx context.Context, decompressReq DecompressRequest) ([]TaskInfo, error)
+	SignedDownloadURL(path string, expire time.Time) (string, error)
+	SignedProxyURL(path string, expire time.Time) (string, error)
+}
+
+// 编译期检查 OpenListAPI 及各管理客户端实现了对应接口
+var (
+	_ Client        = (*OpenListAPI)(nil)
+	_ StorageAdmin  = (*StorageService)(nil)
+	_ UserAdmin     = (*UserService)(nil)
+	_ MetaAdmin     = (*MetaService)(nil)
+	_ SettingAdmin  = (*SettingService)(nil)
+	_ DriverCatalog = (*DriverService)(nil)
+	_ IndexAdmin    = (*IndexService)(nil)
+	_ TaskManager   = (*TaskService)(nil)
+	_ ShareManager  = (*ShareService)(nil)
+)
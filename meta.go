@@ -0,0 +1,60 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+)
+
+// MetaService 路径元信息管理，对应 /api/admin/meta/*
+type MetaService struct {
+	api *OpenListAPI
+}
+
+// Metas 获取元信息管理客户端（需要管理员权限）
+func (c *OpenListAPI) Metas() MetaAdmin {
+	return &MetaService{api: c}
+}
+
+// List 列出元信息
+// page: 页码（默认 1）
+// perPage: 每页条数（0表示不分页）
+func (s *MetaService) List(ctx context.Context, page, perPage int) (*MetaListResponse, error) {
+	listResp := &MetaListResponse{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/meta/list", pageQuery(page, perPage), nil, listResp); err != nil {
+		return nil, fmt.Errorf("列出元信息失败: %w", err)
+	}
+	return listResp, nil
+}
+
+// Get 获取单个元信息
+func (s *MetaService) Get(ctx context.Context, id uint) (*Meta, error) {
+	meta := &Meta{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/meta/get", idQuery(id), nil, meta); err != nil {
+		return nil, fmt.Errorf("获取元信息失败: %w", err)
+	}
+	return meta, nil
+}
+
+// Create 创建元信息
+func (s *MetaService) Create(ctx context.Context, meta *Meta) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/meta/create", nil, meta, nil); err != nil {
+		return fmt.Errorf("创建元信息失败: %w", err)
+	}
+	return nil
+}
+
+// Update 更新元信息（meta.ID 必须有效）
+func (s *MetaService) Update(ctx context.Context, meta *Meta) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/meta/update", nil, meta, nil); err != nil {
+		return fmt.Errorf("更新元信息失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除元信息
+func (s *MetaService) Delete(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/meta/delete", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("删除元信息失败: %w", err)
+	}
+	return nil
+}
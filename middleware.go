@@ -0,0 +1,123 @@
+package openlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RoundTripFunc 发送HTTP请求并返回响应
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware 请求中间件（可修改请求、包装响应，如追踪请求头、自定义认证、审计日志）
+// 调用 next 继续执行后续中间件及实际发送
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Operation 当前请求对应的逻辑操作（中间件可通过 OperationFromContext 获取）
+type Operation struct {
+	Name    string // 操作名称（如 "list"、"get"、"upload"、"download"、"admin.storage.list"）
+	Path    string // 操作涉及的远程路径（可能为空）
+	Attempt int    // 第几次尝试（从1开始，令牌过期重新登录后重试时递增）
+}
+
+// 登录操作名称（登录请求不携带令牌，也不触发重新登录）
+const loginOperation = "auth.login"
+
+type operationKey struct{}
+
+// OperationFromContext 从请求上下文中获取逻辑操作信息
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// WithMiddleware 添加请求中间件（按添加顺序由外到内执行）
+// 中间件作用于所有接口调用、上传和下载请求
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *OpenListAPI) {
+		for _, mw := range middlewares {
+			if mw != nil {
+				c.middlewares = append(c.middlewares, mw)
+			}
+		}
+	}
+}
+
+// send 通过中间件链发送请求
+// client: 实际发送请求的HTTP客户端（上传时可能使用调整过超时的副本）
+func (c *OpenListAPI) send(client *http.Client, req *http.Request, op Operation) (*http.Response, error) {
+	if op.Attempt <= 0 {
+		op.Attempt = 1
+	}
+	// 限流（元数据请求收到响应头后归还并发名额，传输请求在响应体关闭后归还）
+	release, err := c.limits.acquire(req.Context(), op)
+	if err != nil {
+		return nil, fmt.Errorf("等待限流失败: %w", err)
+	}
+	ctx, call := c.startCall(req.Context(), op, req.ContentLength)
+	if isTransfer(op.Name) {
+		call.release = release
+	} else {
+		defer release()
+	}
+	req = req.WithContext(context.WithValue(ctx, operationKey{}, op))
+
+	next := RoundTripFunc(client.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	start := time.Now()
+	resp, err := next(req)
+	if err != nil {
+		c.logCall(req, op, nil, responseInfo{}, err, time.Since(start), 0)
+		call.finish(nil, responseInfo{}, err)
+		return nil, err
+	}
+
+	// 响应体关闭后按实际读取的字节数记录日志
+	info := inspectResponse(resp, op)
+	c.limits.learn(op.Path, info.Provider)
+	call.log = func(received int64) {
+		c.logCall(req, op, resp, info, nil, time.Since(start), received)
+	}
+	call.finish(resp, info, nil)
+	return resp, nil
+}
+
+// operationFor 根据接口地址和请求体推断逻辑操作
+// 文件系统接口去掉 "fs" 前缀（如 /api/fs/list → "list"），其余接口按路径拼接（如 /api/admin/storage/list → "admin.storage.list"）
+func operationFor(reqURL string, body []byte) Operation {
+	op := Operation{Attempt: 1}
+
+	parsed, err := url.Parse(reqURL)
+	if err != nil {
+		return op
+	}
+	name := strings.TrimPrefix(parsed.Path, "/api/")
+	name = strings.TrimPrefix(name, "fs/")
+	op.Name = strings.ReplaceAll(strings.Trim(name, "/"), "/", ".")
+
+	// 从请求体或查询参数中提取远程路径
+	var fields struct {
+		Path   string `json:"path"`
+		Dir    string `json:"dir"`
+		SrcDir string `json:"src_dir"`
+		Parent string `json:"parent"`
+	}
+	if len(body) > 0 && json.Unmarshal(body, &fields) == nil {
+		for _, p := range []string{fields.Path, fields.Dir, fields.SrcDir, fields.Parent} {
+			if p != "" {
+				op.Path = p
+				break
+			}
+		}
+	}
+	if op.Path == "" {
+		op.Path = parsed.Query().Get("path")
+	}
+	return op
+}
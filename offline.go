@@ -0,0 +1,66 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+)
+
+// OfflineTool 离线下载工具
+type OfflineTool string
+
+const (
+	OfflineToolAria2        OfflineTool = "aria2"        // aria2
+	OfflineToolQBittorrent  OfflineTool = "qBittorrent"  // qBittorrent
+	OfflineToolTransmission OfflineTool = "Transmission" // Transmission
+	OfflineTool115          OfflineTool = "115 Cloud"    // 115网盘云下载
+	OfflineToolPikPak       OfflineTool = "PikPak"       // PikPak云下载
+	OfflineToolSimpleHttp   OfflineTool = "SimpleHttp"   // OpenList内置HTTP下载
+)
+
+// DeletePolicy 离线下载临时文件删除策略
+type DeletePolicy string
+
+const (
+	DeleteOnUploadSucceed DeletePolicy = "delete_on_upload_succeed" // 上传成功后删除
+	DeleteOnUploadFailed  DeletePolicy = "delete_on_upload_failed"  // 上传失败后删除
+	DeleteNever           DeletePolicy = "delete_never"             // 从不删除
+	DeleteAlways          DeletePolicy = "delete_always"            // 总是删除
+	UploadDownloadStream  DeletePolicy = "upload_download_stream"   // 边下载边上传（不落盘）
+)
+
+// AddOfflineDownload 添加离线下载任务（服务端拉取URL、磁力链接或种子到存储）
+// urls: 下载地址列表
+// destPath: 远程保存目录（如 "/downloads"）
+// tool: 下载工具
+// deletePolicy: 临时文件删除策略
+// 返回值: 创建的任务列表，错误信息
+func (c *OpenListAPI) AddOfflineDownload(ctx context.Context, urls []string, destPath string, tool OfflineTool, deletePolicy DeletePolicy) ([]TaskInfo, error) {
+	// 先检查登录状态
+	if err := c.ensureLogin("添加离线下载"); err != nil {
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("下载地址不能为空")
+	}
+
+	// 构造请求体
+	offlineReq := OfflineDownloadRequest{
+		URLs:         urls,
+		Path:         destPath,
+		Tool:         string(tool),
+		DeletePolicy: string(deletePolicy),
+	}
+
+	// 执行请求
+	offlineResp := &OfflineDownloadResponse{}
+	if err := c.doRequestContext(ctx, &HTTPRequest{
+		Method: "POST",
+		URL:    fmt.Sprintf("%s/api/fs/add_offline_download", c.baseURL),
+		Body:   offlineReq,
+	}, offlineResp); err != nil {
+		return nil, fmt.Errorf("添加离线下载失败: %w", err)
+	}
+
+	return offlineResp.Tasks, nil
+}
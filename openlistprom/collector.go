@@ -0,0 +1,67 @@
+// Package openlistprom 将 OpenList 客户端统计导出为 Prometheus 指标
+//
+//	api := openlist.NewOpenListAPI(baseURL, "admin", password, "")
+//	prometheus.MustRegister(openlistprom.NewCollector(api.Stats(), nil))
+package openlistprom
+
+import (
+	openlist "github.com/littleboss01/openlistClient"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector 实现 prometheus.Collector，每次采集时读取客户端统计快照
+type Collector struct {
+	stats *openlist.Stats
+
+	requests        *prometheus.Desc
+	bytesUploaded   *prometheus.Desc
+	bytesDownloaded *prometheus.Desc
+	activeTransfers *prometheus.Desc
+	logins          *prometheus.Desc
+	relogins        *prometheus.Desc
+	proxyFallbacks  *prometheus.Desc
+}
+
+// NewCollector 创建客户端统计采集器
+// constLabels: 附加的固定标签（可选，如区分多个客户端实例）
+func NewCollector(stats *openlist.Stats, constLabels prometheus.Labels) *Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("openlist", "client", name), help, labels, constLabels)
+	}
+	return &Collector{
+		stats:           stats,
+		requests:        desc("requests_total", "按操作和结果统计的请求数", "operation", "outcome"),
+		bytesUploaded:   desc("uploaded_bytes_total", "上传字节数"),
+		bytesDownloaded: desc("downloaded_bytes_total", "下载字节数"),
+		activeTransfers: desc("active_transfers", "进行中的上传/下载数"),
+		logins:          desc("logins_total", "登录次数（含重新登录）"),
+		relogins:        desc("relogins_total", "令牌过期后重新登录次数"),
+		proxyFallbacks:  desc("proxy_fallbacks_total", "代理地址无效而改为直连的次数"),
+	}
+}
+
+// Describe 实现 prometheus.Collector 接口
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requests
+	ch <- c.bytesUploaded
+	ch <- c.bytesDownloaded
+	ch <- c.activeTransfers
+	ch <- c.logins
+	ch <- c.relogins
+	ch <- c.proxyFallbacks
+}
+
+// Collect 实现 prometheus.Collector 接口
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	snapshot := c.stats.Snapshot()
+
+	for _, r := range snapshot.Requests {
+		ch <- prometheus.MustNewConstMetric(c.requests, prometheus.CounterValue, float64(r.Count), r.Operation, r.Outcome)
+	}
+	ch <- prometheus.MustNewConstMetric(c.bytesUploaded, prometheus.CounterValue, float64(snapshot.BytesUploaded))
+	ch <- prometheus.MustNewConstMetric(c.bytesDownloaded, prometheus.CounterValue, float64(snapshot.BytesDownloaded))
+	ch <- prometheus.MustNewConstMetric(c.activeTransfers, prometheus.GaugeValue, float64(snapshot.ActiveTransfers))
+	ch <- prometheus.MustNewConstMetric(c.logins, prometheus.CounterValue, float64(snapshot.Logins))
+	ch <- prometheus.MustNewConstMetric(c.relogins, prometheus.CounterValue, float64(snapshot.Relogins))
+	ch <- prometheus.MustNewConstMetric(c.proxyFallbacks, prometheus.CounterValue, float64(snapshot.ProxyFallbacks))
+}
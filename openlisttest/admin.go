@@ -0,0 +1,323 @@
+package openlisttest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// adminState 管理接口数据（设置、存储、元信息、用户、搜索索引）
+type adminState struct {
+	settings map[string]openlist.SettingItem
+	storages []openlist.Storage
+	metas    []openlist.Meta
+	users    []openlist.User
+	index    openlist.IndexProgress
+	nextID   uint
+}
+
+// newAdminState 创建管理数据（内置 admin 管理员和 guest 访客，与 OpenList 一致）
+func newAdminState(username, password string) adminState {
+	return adminState{
+		settings: map[string]openlist.SettingItem{},
+		users: []openlist.User{
+			{ID: 1, Username: username, Password: password, BasePath: "/", Role: openlist.RoleAdmin},
+			{ID: 2, Username: "guest", BasePath: "/", Role: openlist.RoleGuest, Disabled: true},
+		},
+		nextID: 3,
+	}
+}
+
+// AddSetting 添加或覆盖设置项
+func (s *Server) AddSetting(item openlist.SettingItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.admin.settings[item.Key] = item
+}
+
+// AddStorage 添加存储，返回存储ID
+func (s *Server) AddStorage(storage openlist.Storage) uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	storage.ID = s.admin.allocID()
+	storage.Status = "work"
+	storage.Modified = time.Now()
+	s.admin.storages = append(s.admin.storages, storage)
+	return storage.ID
+}
+
+// AddMeta 添加元信息
+func (s *Server) AddMeta(meta openlist.Meta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta.ID = s.admin.allocID()
+	s.admin.metas = append(s.admin.metas, meta)
+}
+
+// AddUser 添加用户（Password 为明文）
+func (s *Server) AddUser(user openlist.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user.ID = s.admin.allocID()
+	s.admin.users = append(s.admin.users, user)
+}
+
+// Storages 获取所有存储
+func (s *Server) Storages() []openlist.Storage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]openlist.Storage(nil), s.admin.storages...)
+}
+
+// Metas 获取所有元信息
+func (s *Server) Metas() []openlist.Meta {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]openlist.Meta(nil), s.admin.metas...)
+}
+
+// Users 获取所有用户（含明文密码，便于断言）
+func (s *Server) Users() []openlist.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]openlist.User(nil), s.admin.users...)
+}
+
+// IndexProgress 获取搜索索引进度
+func (s *Server) IndexProgress() openlist.IndexProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.admin.index
+}
+
+// countIndexed 统计索引路径下的对象数量（paths 为空表示全部，maxDepth<0 表示不限制深度，调用方需持有锁）
+func (s *Server) countIndexed(paths []string, maxDepth int) uint64 {
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+	indexed := map[string]bool{}
+	for _, root := range paths {
+		root = cleanPath(root)
+		for _, p := range s.subtree(root) {
+			rel := strings.Trim(strings.TrimPrefix(p, root), "/")
+			if p == "/" || (maxDepth >= 0 && rel != "" && strings.Count(rel, "/")+1 > maxDepth) {
+				continue
+			}
+			indexed[p] = true
+		}
+	}
+	return uint64(len(indexed))
+}
+
+// allocID 分配ID（调用方需持有锁）
+func (a *adminState) allocID() uint {
+	id := a.nextID
+	a.nextID++
+	return id
+}
+
+// handleAdmin 管理接口（/api/admin/{setting,storage,meta,user,index}/{action}）
+func (s *Server) handleAdmin(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/admin/"), "/")
+	if len(parts) != 2 {
+		writeJSON(w, 404, "not found", nil)
+		return
+	}
+
+	switch parts[0] + "/" + parts[1] {
+	case "setting/list":
+		s.mu.Lock()
+		items := make([]openlist.SettingItem, 0, len(s.admin.settings))
+		for _, item := range s.admin.settings {
+			items = append(items, item)
+		}
+		s.mu.Unlock()
+		sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+		writeJSON(w, 200, "success", items)
+
+	case "setting/save":
+		var items []openlist.SettingItem
+		if !decode(w, r, &items) {
+			return
+		}
+		s.mu.Lock()
+		for _, item := range items {
+			s.admin.settings[item.Key] = item
+		}
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", nil)
+
+	case "storage/list":
+		s.mu.Lock()
+		storages := append([]openlist.Storage{}, s.admin.storages...)
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", openlist.StorageListResponse{Content: storages, Total: len(storages)})
+
+	case "storage/create":
+		var storage openlist.Storage
+		if !decode(w, r, &storage) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, existing := range s.admin.storages {
+			if existing.MountPath == storage.MountPath {
+				writeJSON(w, 500, "mount path is already exists", nil)
+				return
+			}
+		}
+		storage.ID = s.admin.allocID()
+		storage.Status = "work"
+		storage.Modified = time.Now()
+		s.admin.storages = append(s.admin.storages, storage)
+		writeJSON(w, 200, "success", openlist.CreateStorageResponse{ID: storage.ID})
+
+	case "storage/update":
+		var storage openlist.Storage
+		if !decode(w, r, &storage) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := range s.admin.storages {
+			if s.admin.storages[i].ID == storage.ID {
+				storage.Status = "work"
+				storage.Modified = time.Now()
+				s.admin.storages[i] = storage
+				writeJSON(w, 200, "success", nil)
+				return
+			}
+		}
+		writeJSON(w, 500, "storage not found", nil)
+
+	case "meta/list":
+		s.mu.Lock()
+		metas := append([]openlist.Meta{}, s.admin.metas...)
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", openlist.MetaListResponse{Content: metas, Total: len(metas)})
+
+	case "meta/create", "meta/update":
+		var meta openlist.Meta
+		if !decode(w, r, &meta) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := range s.admin.metas {
+			if parts[1] == "create" && s.admin.metas[i].Path == meta.Path {
+				writeJSON(w, 500, "meta with path already exists", nil)
+				return
+			}
+			if parts[1] == "update" && s.admin.metas[i].ID == meta.ID {
+				s.admin.metas[i] = meta
+				writeJSON(w, 200, "success", nil)
+				return
+			}
+		}
+		if parts[1] == "update" {
+			writeJSON(w, 500, "meta not found", nil)
+			return
+		}
+		meta.ID = s.admin.allocID()
+		s.admin.metas = append(s.admin.metas, meta)
+		writeJSON(w, 200, "success", nil)
+
+	case "user/list":
+		s.mu.Lock()
+		users := make([]openlist.User, len(s.admin.users))
+		for i, user := range s.admin.users {
+			user.Password = "" // 服务端不返回密码
+			users[i] = user
+		}
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", openlist.UserListResponse{Content: users, Total: len(users)})
+
+	case "user/create":
+		var user openlist.User
+		if !decode(w, r, &user) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, existing := range s.admin.users {
+			if existing.Username == user.Username {
+				writeJSON(w, 500, "user already exists", nil)
+				return
+			}
+		}
+		if user.Role == openlist.RoleAdmin || user.Role == openlist.RoleGuest {
+			writeJSON(w, 400, "admin or guest user can not be created", nil)
+			return
+		}
+		user.ID = s.admin.allocID()
+		s.admin.users = append(s.admin.users, user)
+		writeJSON(w, 200, "success", nil)
+
+	case "user/update":
+		var user openlist.User
+		if !decode(w, r, &user) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := range s.admin.users {
+			if s.admin.users[i].ID == user.ID {
+				if user.Password == "" {
+					user.Password = s.admin.users[i].Password // 密码为空时保持原密码
+				}
+				s.admin.users[i] = user
+				writeJSON(w, 200, "success", nil)
+				return
+			}
+		}
+		writeJSON(w, 500, "user not found", nil)
+
+	case "index/build", "index/update":
+		var req openlist.IndexRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if parts[1] == "update" && len(req.Paths) == 0 {
+			writeJSON(w, 400, "paths is required", nil)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		count := s.countIndexed(req.Paths, req.MaxDepth)
+		if parts[1] == "update" {
+			count += s.admin.index.ObjCount
+		}
+		now := time.Now()
+		s.admin.index = openlist.IndexProgress{ObjCount: count, IsDone: true, LastDoneTime: &now}
+		writeJSON(w, 200, "success", nil)
+
+	case "index/stop":
+		writeJSON(w, 200, "success", nil)
+
+	case "index/clear":
+		s.mu.Lock()
+		s.admin.index = openlist.IndexProgress{}
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", nil)
+
+	case "index/progress":
+		s.mu.Lock()
+		progress := s.admin.index
+		s.mu.Unlock()
+		writeJSON(w, 200, "success", progress)
+
+	default:
+		writeJSON(w, 404, "not found", nil)
+	}
+}
@@ -0,0 +1,241 @@
+package openlisttest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// archiveSign 压缩包内文件下载签名
+const archiveSign = "fake-archive-sign"
+
+// fakeArchive 模拟压缩包（内部路径 → 文件内容）
+type fakeArchive struct {
+	password string
+	files    map[string][]byte
+}
+
+// AddArchive 添加压缩包（在文件树中创建同名文件，自动创建上级目录）
+// files: 压缩包内文件（内部路径 → 内容，如 "/docs/readme.md"）
+// password: 压缩包密码（为空表示未加密）
+func (s *Server) AddArchive(archivePath string, files map[string][]byte, password string) {
+	archive := &fakeArchive{password: password, files: map[string][]byte{}}
+	for p, content := range files {
+		archive.files[cleanPath(p)] = append([]byte(nil), content...)
+	}
+
+	s.AddFile(archivePath, []byte("archive"))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.archives == nil {
+		s.archives = map[string]*fakeArchive{}
+	}
+	s.archives[cleanPath(archivePath)] = archive
+}
+
+// archive 查找压缩包并校验密码（调用方需持有锁，失败时写入错误响应）
+func (s *Server) archive(w http.ResponseWriter, archivePath, password string) (*fakeArchive, bool) {
+	archive, ok := s.archives[cleanPath(archivePath)]
+	if !ok {
+		writeJSON(w, 500, "failed get archive meta: object not found", nil)
+		return nil, false
+	}
+	if archive.password != "" && archive.password != password {
+		writeJSON(w, 202, "wrong archive password", nil)
+		return nil, false
+	}
+	return archive, true
+}
+
+// entries 压缩包内目录的直接子项（名称 → 是否为目录，按名称排序）
+func (a *fakeArchive) entries(dirPath string) ([]string, map[string]bool) {
+	dirPath = cleanPath(dirPath)
+	isDir := map[string]bool{}
+	for p := range a.files {
+		if !strings.HasPrefix(p, strings.TrimSuffix(dirPath, "/")+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(p, strings.TrimSuffix(dirPath, "/")+"/")
+		name, _, nested := strings.Cut(rest, "/")
+		isDir[name] = isDir[name] || nested
+	}
+	names := make([]string, 0, len(isDir))
+	for name := range isDir {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, isDir
+}
+
+// tree 压缩包内目录的文件树
+func (a *fakeArchive) tree(dirPath string) []openlist.ArchiveContent {
+	names, isDir := a.entries(dirPath)
+	content := make([]openlist.ArchiveContent, 0, len(names))
+	for _, name := range names {
+		p := path.Join(dirPath, name)
+		item := openlist.ArchiveContent{Name: name, IsDir: isDir[name], Size: int64(len(a.files[p]))}
+		if item.IsDir {
+			item.Children = a.tree(p)
+		}
+		content = append(content, item)
+	}
+	return content
+}
+
+// handleArchiveMeta 压缩包元信息
+func (s *Server) handleArchiveMeta(w http.ResponseWriter, r *http.Request) {
+	var req openlist.ArchiveMetaRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	archive, ok := s.archive(w, req.Path, req.ArchivePass)
+	if !ok {
+		return
+	}
+	writeJSON(w, 200, "success", openlist.ArchiveMeta{
+		Encrypted: archive.password != "",
+		Content:   archive.tree("/"),
+		RawURL:    s.URL + "/d" + cleanPath(req.Path),
+		Sign:      archiveSign,
+	})
+}
+
+// handleArchiveList 列出压缩包内目录
+func (s *Server) handleArchiveList(w http.ResponseWriter, r *http.Request) {
+	var req openlist.ArchiveListRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	archive, ok := s.archive(w, req.Path, req.ArchivePass)
+	if !ok {
+		return
+	}
+	names, isDir := archive.entries(req.InnerPath)
+	if len(names) == 0 && cleanPath(req.InnerPath) != "/" {
+		writeJSON(w, 500, "object not found", nil)
+		return
+	}
+	total := len(names)
+	if req.PerPage > 0 {
+		start := min((max(req.Page, 1)-1)*req.PerPage, total)
+		names = names[start:min(start+req.PerPage, total)]
+	}
+
+	content := make([]openlist.FileInfo, 0, len(names))
+	for _, name := range names {
+		item := openlist.FileInfo{Name: name, IsDir: isDir[name], Type: 1}
+		if !item.IsDir {
+			item.Size = int64(len(archive.files[path.Join(cleanPath(req.InnerPath), name)]))
+			item.Type = 0
+		}
+		content = append(content, item)
+	}
+	writeJSON(w, 200, "success", openlist.ListResponse{
+		Content:  content,
+		Total:    total,
+		Page:     req.Page,
+		PerPage:  req.PerPage,
+		Provider: Provider,
+	})
+}
+
+// handleDecompress 解压（立即将压缩包内指定路径下的文件写入目标目录，并创建已完成的 decompress 任务）
+func (s *Server) handleDecompress(w http.ResponseWriter, r *http.Request) {
+	var req openlist.DecompressRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dst, ok := s.entries[cleanPath(req.DstDir)]; !ok || !dst.isDir {
+		writeJSON(w, 500, "failed get dst dir: object not found", nil)
+		return
+	}
+	if s.tasks == nil {
+		s.tasks = map[string]*fakeTask{}
+	}
+
+	inner := strings.TrimSuffix(cleanPath(req.InnerPath), "/")
+	tasks := []openlist.TaskInfo{}
+	for _, name := range req.Name {
+		archivePath := path.Join(cleanPath(req.SrcDir), name)
+		archive, ok := s.archive(w, archivePath, req.ArchivePass)
+		if !ok {
+			return
+		}
+
+		dstDir := cleanPath(req.DstDir)
+		if req.PutIntoNewDir {
+			dstDir = path.Join(dstDir, strings.TrimSuffix(name, path.Ext(name)))
+		}
+		for p, content := range archive.files {
+			rel, ok := strings.CutPrefix(p, inner+"/")
+			if !ok && p != inner {
+				continue
+			}
+			if p == inner {
+				rel = path.Base(p)
+			}
+			target := path.Join(dstDir, rel)
+			if _, exists := s.entries[target]; exists && !req.Overwrite {
+				continue
+			}
+			s.mkdirAll(path.Dir(target))
+			s.entries[target] = &entry{content: append([]byte(nil), content...), modified: time.Now()}
+		}
+
+		s.taskSeq++
+		info := openlist.TaskInfo{
+			ID:       fmt.Sprintf("decompress-%d", s.taskSeq),
+			Name:     fmt.Sprintf("decompress [%s](%s) to [%s]", archivePath, cleanPath(req.InnerPath), dstDir),
+			Creator:  s.Username,
+			State:    openlist.TaskSucceeded,
+			Progress: 100,
+		}
+		s.tasks[info.ID] = &fakeTask{taskType: "decompress", info: info}
+		tasks = append(tasks, info)
+	}
+	writeJSON(w, 200, "success", openlist.DecompressResponse{Tasks: tasks})
+}
+
+// handleArchiveDownload 下载压缩包内文件（/ad/<压缩包路径>?inner=...&pass=...&sign=...）
+func (s *Server) handleArchiveDownload(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("sign") != archiveSign {
+		http.Error(w, "sign mismatch", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	archive, ok := s.archives[cleanPath(strings.TrimPrefix(r.URL.Path, "/ad/"))]
+	var content []byte
+	var found bool
+	if ok {
+		content, found = archive.files[cleanPath(query.Get("inner"))]
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !ok || !found:
+		http.NotFound(w, r)
+	case archive.password != "" && archive.password != query.Get("pass"):
+		http.Error(w, "wrong archive password", http.StatusForbidden)
+	default:
+		w.Write(content)
+	}
+}
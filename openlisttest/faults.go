@@ -0,0 +1,89 @@
+package openlisttest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// faults 故障注入配置
+type faults struct {
+	latency    time.Duration // 每个请求的额外延迟
+	failNext   int           // 接下来需要失败的请求数
+	failStatus int           // 失败时返回的HTTP状态码
+	truncateAt int           // 下载响应体截断位置（<0 表示不截断）
+}
+
+// SetLatency 为之后的每个请求增加固定延迟（0表示取消）
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults.latency = d
+}
+
+// FailNext 让接下来的 n 个请求直接返回指定的HTTP状态码（如 503）
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults.failNext = n
+	s.faults.failStatus = status
+}
+
+// ExpireTokens 使所有已签发的令牌失效（之后的请求返回 401 "token is expired"，需重新登录）
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+// TruncateDownloads 下载时只返回前 n 字节后断开连接（n<0 表示恢复正常）
+func (s *Server) TruncateDownloads(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults.truncateAt = n
+}
+
+// withFaults 故障注入中间件（同时统计接口调用次数、记录请求头和JSON请求体）
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// JSON请求体读取后还原（上传等二进制请求体不缓存）
+		var body []byte
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			body, _ = io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		s.mu.Lock()
+		s.calls[r.URL.Path]++
+		s.headers[r.URL.Path] = r.Header.Clone()
+		if body != nil {
+			s.bodies[r.URL.Path] = body
+		}
+		latency := s.faults.latency
+		fail := s.faults.failNext > 0
+		status := s.faults.failStatus
+		if fail {
+			s.faults.failNext--
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fail {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
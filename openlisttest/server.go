@@ -0,0 +1,597 @@
+// Package openlisttest 提供基于 httptest 的 OpenList 模拟服务，用于离线测试
+//
+// 模拟服务在内存中维护一棵文件树，实现登录、列目录、获取文件信息、搜索、
+// 新建目录、删除、重命名、移动、复制、表单上传、流式上传、直链下载、离线下载、
+// 压缩包浏览与解压、分享、后台任务接口，
+// 以及设置、存储、元信息、用户、搜索索引的管理接口，
+// 并支持故障注入（延迟、5xx、令牌过期、响应体截断）。
+package openlisttest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// 默认账号
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "admin"
+)
+
+// Provider 模拟服务返回的存储驱动名称
+const Provider = "Fake"
+
+// entry 内存文件树中的文件或目录
+type entry struct {
+	isDir    bool
+	content  []byte
+	modified time.Time
+}
+
+// Server OpenList 模拟服务
+type Server struct {
+	*httptest.Server
+
+	Username string // 登录用户名
+	Password string // 登录密码
+
+	mu       sync.Mutex
+	entries  map[string]*entry       // 规范化路径 → 文件/目录
+	tokens   map[string]bool         // 有效令牌
+	nextID   int                     // 令牌序号
+	faults   faults                  // 故障注入配置
+	calls    map[string]int          // 各接口调用次数
+	headers  map[string]http.Header  // 各接口最近一次请求的请求头
+	bodies   map[string][]byte       // 各接口最近一次请求的JSON请求体
+	tasks    map[string]*fakeTask    // 后台任务（任务ID → 任务）
+	archives map[string]*fakeArchive // 压缩包（路径 → 压缩包）
+	taskSeq  int                     // 任务序号
+	shares   []openlist.Share        // 分享
+	shareSeq int                     // 分享序号
+	hashes   []openlist.HashType     // 文件信息中返回的哈希类型（nil 表示全部）
+	admin    adminState              // 管理接口数据
+}
+
+// NewServer 创建并启动模拟服务（使用默认账号 admin/admin），测试结束后需调用 Close
+func NewServer() *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		entries:  map[string]*entry{"/": {isDir: true, modified: time.Now()}},
+		tokens:   map[string]bool{},
+		calls:    map[string]int{},
+		headers:  map[string]http.Header{},
+		bodies:   map[string][]byte{},
+		faults:   faults{truncateAt: -1},
+		admin:    newAdminState(DefaultUsername, DefaultPassword),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth/login", s.handleLogin)
+	mux.HandleFunc("/api/fs/list", s.auth(s.handleList))
+	mux.HandleFunc("/api/fs/get", s.auth(s.handleGet))
+	mux.HandleFunc("/api/fs/search", s.auth(s.handleSearch))
+	mux.HandleFunc("/api/fs/mkdir", s.auth(s.handleMkdir))
+	mux.HandleFunc("/api/fs/remove", s.auth(s.handleRemove))
+	mux.HandleFunc("/api/fs/rename", s.auth(s.handleRename))
+	mux.HandleFunc("/api/fs/move", s.auth(s.handleMoveCopy(true)))
+	mux.HandleFunc("/api/fs/copy", s.auth(s.handleMoveCopy(false)))
+	mux.HandleFunc("/api/fs/form", s.auth(s.handleForm))
+	mux.HandleFunc("/api/fs/put", s.auth(s.handlePut))
+	mux.HandleFunc("/api/fs/add_offline_download", s.auth(s.handleOfflineDownload))
+	mux.HandleFunc("/api/fs/archive/meta", s.auth(s.handleArchiveMeta))
+	mux.HandleFunc("/api/fs/archive/list", s.auth(s.handleArchiveList))
+	mux.HandleFunc("/api/fs/archive/decompress", s.auth(s.handleDecompress))
+	mux.HandleFunc("/api/task/", s.auth(s.handleTask))
+	mux.HandleFunc("/api/share/", s.auth(s.handleShare))
+	mux.HandleFunc("/api/admin/", s.auth(s.handleAdmin))
+	mux.HandleFunc("/d/", s.handleDownload)
+	mux.HandleFunc("/p/", s.handleDownload)
+	mux.HandleFunc("/ad/", s.handleArchiveDownload)
+
+	s.Server = httptest.NewServer(s.withFaults(mux))
+	return s
+}
+
+// AddFile 添加文件（自动创建上级目录，已存在时覆盖）
+func (s *Server) AddFile(filePath string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filePath = cleanPath(filePath)
+	s.mkdirAll(path.Dir(filePath))
+	s.entries[filePath] = &entry{content: append([]byte(nil), content...), modified: time.Now()}
+}
+
+// AddDir 添加目录（自动创建上级目录）
+func (s *Server) AddDir(dirPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mkdirAll(cleanPath(dirPath))
+}
+
+// ReadFile 读取文件内容
+func (s *Server) ReadFile(filePath string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[cleanPath(filePath)]
+	if !ok || e.isDir {
+		return nil, false
+	}
+	return append([]byte(nil), e.content...), true
+}
+
+// Exists 判断路径是否存在
+func (s *Server) Exists(p string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.entries[cleanPath(p)]
+	return ok
+}
+
+// Calls 获取接口调用次数（如 Calls("/api/fs/list")）
+func (s *Server) Calls(apiPath string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[apiPath]
+}
+
+// LastHeader 获取接口最近一次请求的请求头（未调用时返回nil）
+func (s *Server) LastHeader(apiPath string) http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.headers[apiPath].Clone()
+}
+
+// LastBody 获取接口最近一次请求的JSON请求体（未调用时返回nil）
+func (s *Server) LastBody(apiPath string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return bytes.Clone(s.bodies[apiPath])
+}
+
+// cleanPath 规范化远程路径
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
+
+// mkdirAll 逐级创建目录（调用方需持有锁）
+func (s *Server) mkdirAll(dirPath string) {
+	for dirPath != "/" {
+		if _, ok := s.entries[dirPath]; !ok {
+			s.entries[dirPath] = &entry{isDir: true, modified: time.Now()}
+		}
+		dirPath = path.Dir(dirPath)
+	}
+}
+
+// children 获取目录的直接子项名称（按名称排序，调用方需持有锁）
+func (s *Server) children(dirPath string) []string {
+	var names []string
+	for p := range s.entries {
+		if p != "/" && path.Dir(p) == dirPath {
+			names = append(names, path.Base(p))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subtree 获取路径及其所有子孙路径（调用方需持有锁）
+func (s *Server) subtree(root string) []string {
+	var paths []string
+	for p := range s.entries {
+		if p == root || strings.HasPrefix(p, strings.TrimSuffix(root, "/")+"/") {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// SetHashTypes 设置文件信息中返回的哈希类型（不传参数表示不返回哈希，模拟 Local 等不提供哈希的存储）
+func (s *Server) SetHashTypes(types ...openlist.HashType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes = append([]openlist.HashType{}, types...)
+}
+
+// fileInfo 构造文件信息（调用方需持有锁）
+func (s *Server) fileInfo(p string, e *entry, withURL bool) openlist.FileInfo {
+	info := openlist.FileInfo{
+		Name:     path.Base(p),
+		IsDir:    e.isDir,
+		Modified: e.modified,
+		Created:  e.modified,
+		Type:     1,
+	}
+	if !e.isDir {
+		info.Size = int64(len(e.content))
+		info.Type = 0
+		info.Hash_info = hashesOf(e.content)
+		if s.hashes != nil {
+			hashes := openlist.HashInfo{}
+			for _, t := range s.hashes {
+				if value, ok := info.Hash_info[t]; ok {
+					hashes[t] = value
+				}
+			}
+			info.Hash_info = hashes
+		}
+		info.Sign = "fake-sign"
+	}
+	if withURL && !e.isDir {
+		info.Raw_url = s.URL + "/d" + p + "?sign=" + info.Sign
+	}
+	return info
+}
+
+// hashesOf 计算内容哈希
+func hashesOf(content []byte) openlist.HashInfo {
+	md5Sum := md5.Sum(content)
+	sha1Sum := sha1.Sum(content)
+	sha256Sum := sha256.Sum256(content)
+	return openlist.HashInfo{
+		openlist.HashMD5:    hex.EncodeToString(md5Sum[:]),
+		openlist.HashSHA1:   hex.EncodeToString(sha1Sum[:]),
+		openlist.HashSHA256: hex.EncodeToString(sha256Sum[:]),
+	}
+}
+
+// writeJSON 写入统一格式的响应（HTTP状态码始终为200，业务状态码在 code 字段中）
+func writeJSON(w http.ResponseWriter, code int, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    code,
+		"message": message,
+		"data":    data,
+	})
+}
+
+// decode 解析JSON请求体，失败时写入错误响应
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, 400, fmt.Sprintf("invalid request body: %v", err), nil)
+		return false
+	}
+	return true
+}
+
+// auth 令牌校验中间件
+func (s *Server) auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		valid := s.tokens[r.Header.Get("Authorization")]
+		s.mu.Unlock()
+
+		if !valid {
+			writeJSON(w, 401, "token is expired", nil)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleLogin 登录
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req openlist.LoginRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Username != s.Username || req.Password != s.Password {
+		writeJSON(w, 400, "password is incorrect", nil)
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	token := fmt.Sprintf("fake-token-%d", s.nextID)
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, 200, "success", openlist.LoginResponse{Token: token})
+}
+
+// handleList 列目录
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	var req openlist.ListRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dirPath := cleanPath(req.Path)
+	dir, ok := s.entries[dirPath]
+	if !ok || !dir.isDir {
+		writeJSON(w, 500, "object not found", nil)
+		return
+	}
+
+	names := s.children(dirPath)
+	total := len(names)
+	if req.PerPage > 0 {
+		start := min((max(req.Page, 1)-1)*req.PerPage, total)
+		names = names[start:min(start+req.PerPage, total)]
+	}
+
+	content := make([]openlist.FileInfo, 0, len(names))
+	for _, name := range names {
+		p := path.Join(dirPath, name)
+		content = append(content, s.fileInfo(p, s.entries[p], false))
+	}
+	writeJSON(w, 200, "success", openlist.ListResponse{
+		Content:  content,
+		Total:    total,
+		Page:     req.Page,
+		PerPage:  req.PerPage,
+		Write:    true,
+		Provider: Provider,
+	})
+}
+
+// handleGet 获取文件信息
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	var req openlist.FileInfoRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := cleanPath(req.Path)
+	e, ok := s.entries[p]
+	if !ok {
+		writeJSON(w, 500, "failed get obj: object not found", nil)
+		return
+	}
+	writeJSON(w, 200, "success", s.fileInfo(p, e, true))
+}
+
+// handleSearch 搜索（按名称包含关键词匹配）
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var req openlist.SearchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []openlist.SearchItem
+	for _, p := range s.subtree(cleanPath(req.Parent)) {
+		e := s.entries[p]
+		if p == "/" || !strings.Contains(path.Base(p), req.Keywords) {
+			continue
+		}
+		if (req.Scope == openlist.SearchScopeFolders && !e.isDir) || (req.Scope == openlist.SearchScopeFiles && e.isDir) {
+			continue
+		}
+		matched = append(matched, openlist.SearchItem{
+			Parent:   path.Dir(p),
+			Name:     path.Base(p),
+			IsDir:    e.isDir,
+			Size:     int64(len(e.content)),
+			Modified: e.modified,
+		})
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].FullPath() < matched[j].FullPath() })
+
+	total := len(matched)
+	if req.Per_page > 0 {
+		start := min((max(req.Page, 1)-1)*req.Per_page, total)
+		matched = matched[start:min(start+req.Per_page, total)]
+	}
+	writeJSON(w, 200, "success", openlist.SearchResult{Content: matched, Total: total})
+}
+
+// handleMkdir 新建目录
+func (s *Server) handleMkdir(w http.ResponseWriter, r *http.Request) {
+	var req openlist.MkdirRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := cleanPath(req.Path)
+	if e, ok := s.entries[p]; ok && !e.isDir {
+		writeJSON(w, 500, "file already exists", nil)
+		return
+	}
+	s.mkdirAll(p)
+	writeJSON(w, 200, "success", nil)
+}
+
+// handleRemove 删除
+func (s *Server) handleRemove(w http.ResponseWriter, r *http.Request) {
+	var req openlist.RemoveRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range req.Names {
+		for _, p := range s.subtree(cleanPath(path.Join(req.Dir, name))) {
+			delete(s.entries, p)
+		}
+	}
+	writeJSON(w, 200, "success", nil)
+}
+
+// handleRename 重命名
+func (s *Server) handleRename(w http.ResponseWriter, r *http.Request) {
+	var req openlist.RenameRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	src := cleanPath(req.Path)
+	if _, ok := s.entries[src]; !ok {
+		writeJSON(w, 500, "object not found", nil)
+		return
+	}
+	dst := path.Join(path.Dir(src), req.Name)
+	if _, ok := s.entries[dst]; ok {
+		writeJSON(w, 403, "file exists", nil)
+		return
+	}
+	s.transfer(src, dst, true)
+	writeJSON(w, 200, "success", nil)
+}
+
+// handleMoveCopy 移动/复制
+func (s *Server) handleMoveCopy(move bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req openlist.MoveCopyRequest
+		if !decode(w, r, &req) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		dstDir := cleanPath(req.DstDir)
+		if e, ok := s.entries[dstDir]; !ok || !e.isDir {
+			writeJSON(w, 500, "object not found", nil)
+			return
+		}
+		for _, name := range req.Names {
+			src := cleanPath(path.Join(req.SrcDir, name))
+			if _, ok := s.entries[src]; !ok {
+				writeJSON(w, 500, "object not found", nil)
+				return
+			}
+			s.transfer(src, path.Join(dstDir, name), move)
+		}
+		writeJSON(w, 200, "success", nil)
+	}
+}
+
+// transfer 将 src 子树复制或移动到 dst（调用方需持有锁）
+func (s *Server) transfer(src, dst string, move bool) {
+	for _, p := range s.subtree(src) {
+		e := s.entries[p]
+		target := dst + strings.TrimPrefix(p, src)
+		s.entries[target] = &entry{isDir: e.isDir, content: e.content, modified: time.Now()}
+		if move {
+			delete(s.entries, p)
+		}
+	}
+}
+
+// store 保存上传的文件，遵循 Overwrite 请求头（调用方不持有锁）
+func (s *Server) store(w http.ResponseWriter, r *http.Request, filePath string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filePath = cleanPath(filePath)
+	if _, ok := s.entries[filePath]; ok && r.Header.Get("Overwrite") == "false" {
+		writeJSON(w, 403, "file exists", nil)
+		return
+	}
+	s.mkdirAll(path.Dir(filePath))
+	s.entries[filePath] = &entry{content: content, modified: time.Now()}
+	writeJSON(w, 200, "success", nil)
+}
+
+// handleForm 表单上传
+func (s *Server) handleForm(w http.ResponseWriter, r *http.Request) {
+	filePath, err := url.PathUnescape(r.Header.Get("File-Path"))
+	if err != nil {
+		writeJSON(w, 400, "invalid file path", nil)
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeJSON(w, 400, fmt.Sprintf("invalid form: %v", err), nil)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeJSON(w, 500, err.Error(), nil)
+		return
+	}
+	s.store(w, r, filePath, content)
+}
+
+// handlePut 流式上传
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request) {
+	filePath, err := url.PathUnescape(r.Header.Get("File-Path"))
+	if err != nil {
+		writeJSON(w, 400, "invalid file path", nil)
+		return
+	}
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, 500, err.Error(), nil)
+		return
+	}
+	s.store(w, r, filePath, content)
+}
+
+// handleDownload 直链/代理下载
+func (s *Server) handleDownload(w http.ResponseWriter, r *http.Request) {
+	// 只去掉一个路由前缀（/d/ 或 /p/），避免误伤以 p 开头的目录
+	p, ok := strings.CutPrefix(r.URL.Path, "/d/")
+	if !ok {
+		p, _ = strings.CutPrefix(r.URL.Path, "/p/")
+	}
+
+	s.mu.Lock()
+	e, ok := s.entries[cleanPath(p)]
+	truncate := s.faults.truncateAt
+	s.mu.Unlock()
+
+	if !ok || e.isDir {
+		http.NotFound(w, r)
+		return
+	}
+
+	content := e.content
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+	w.WriteHeader(http.StatusOK)
+	if truncate >= 0 && truncate < len(content) {
+		// 模拟连接中断：声明完整长度但只写入部分数据
+		w.Write(content[:truncate])
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+			}
+		}
+		return
+	}
+	w.Write(content)
+}
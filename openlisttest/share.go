@@ -0,0 +1,111 @@
+package openlisttest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// Shares 获取所有分享
+func (s *Server) Shares() []openlist.Share {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]openlist.Share(nil), s.shares...)
+}
+
+// shareIndex 查找分享下标（调用方需持有锁，未找到时返回 -1）
+func (s *Server) shareIndex(id string) int {
+	for i := range s.shares {
+		if s.shares[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleShare 分享接口（/api/share/{list,get,create,update,delete,enable,disable}）
+func (s *Server) handleShare(w http.ResponseWriter, r *http.Request) {
+	action := strings.TrimPrefix(r.URL.Path, "/api/share/")
+	id := r.URL.Query().Get("id")
+
+	switch action {
+	case "list":
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		s.mu.Lock()
+		shares := append([]openlist.Share{}, s.shares...)
+		s.mu.Unlock()
+		total := len(shares)
+		if perPage > 0 {
+			start := min((max(page, 1)-1)*perPage, total)
+			shares = shares[start:min(start+perPage, total)]
+		}
+		writeJSON(w, 200, "success", openlist.ShareListResponse{Content: shares, Total: total})
+
+	case "get":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if i := s.shareIndex(id); i >= 0 {
+			writeJSON(w, 200, "success", s.shares[i])
+			return
+		}
+		writeJSON(w, 500, "sharing not found", nil)
+
+	case "create", "update":
+		var share openlist.Share
+		if !decode(w, r, &share) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, file := range share.Files {
+			if _, ok := s.entries[cleanPath(file)]; !ok {
+				writeJSON(w, 500, "failed get file: object not found", nil)
+				return
+			}
+		}
+		if action == "create" {
+			s.shareSeq++
+			share.ID = fmt.Sprintf("share%d", s.shareSeq)
+			share.Creator = s.Username
+			share.Accessed = 0
+			s.shares = append(s.shares, share)
+			writeJSON(w, 200, "success", share)
+			return
+		}
+		i := s.shareIndex(share.ID)
+		if i < 0 {
+			writeJSON(w, 500, "sharing not found", nil)
+			return
+		}
+		share.Creator = s.shares[i].Creator
+		share.Accessed = s.shares[i].Accessed
+		s.shares[i] = share
+		writeJSON(w, 200, "success", share)
+
+	case "delete", "enable", "disable":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		i := s.shareIndex(id)
+		if i < 0 {
+			writeJSON(w, 500, "sharing not found", nil)
+			return
+		}
+		switch action {
+		case "delete":
+			s.shares = append(s.shares[:i], s.shares[i+1:]...)
+		case "enable":
+			s.shares[i].Disabled = false
+		case "disable":
+			s.shares[i].Disabled = true
+		}
+		writeJSON(w, 200, "success", nil)
+
+	default:
+		writeJSON(w, 404, "not found", nil)
+	}
+}
@@ -0,0 +1,117 @@
+package openlisttest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// fakeTask 模拟后台任务（每次查询按脚本推进状态）
+type fakeTask struct {
+	taskType string
+	info     openlist.TaskInfo
+	states   []openlist.TaskState // 依次返回的状态（用完后保持最后一个）
+}
+
+// AddTask 添加后台任务
+// taskType: 任务类型（如 "copy"）
+// task: 任务信息（ID 必填）
+// states: 每次查询任务信息时依次返回的状态，用完后保持最后一个（为空时保持 task.State）
+func (s *Server) AddTask(taskType string, task openlist.TaskInfo, states ...openlist.TaskState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tasks == nil {
+		s.tasks = map[string]*fakeTask{}
+	}
+	s.tasks[task.ID] = &fakeTask{taskType: taskType, info: task, states: states}
+}
+
+// handleTask 任务接口（/api/task/{type}/{action}）
+func (s *Server) handleTask(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/task/"), "/")
+	if len(parts) != 2 {
+		writeJSON(w, 404, "not found", nil)
+		return
+	}
+	taskType, action := parts[0], parts[1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 列出任务
+	if action == "done" || action == "undone" {
+		tasks := []openlist.TaskInfo{}
+		for _, task := range s.tasks {
+			if task.taskType == taskType && task.info.State.IsTerminal() == (action == "done") {
+				tasks = append(tasks, task.info)
+			}
+		}
+		writeJSON(w, 200, "success", tasks)
+		return
+	}
+
+	task, ok := s.tasks[r.URL.Query().Get("tid")]
+	if !ok || task.taskType != taskType {
+		writeJSON(w, 500, "task not found", nil)
+		return
+	}
+
+	switch action {
+	case "info":
+		if len(task.states) > 0 {
+			task.info.State = task.states[0]
+			if len(task.states) > 1 {
+				task.states = task.states[1:]
+			}
+		}
+		writeJSON(w, 200, "success", task.info)
+	case "cancel":
+		task.info.State = openlist.TaskCanceled
+		task.states = nil
+		writeJSON(w, 200, "success", nil)
+	case "delete":
+		delete(s.tasks, task.info.ID)
+		writeJSON(w, 200, "success", nil)
+	default:
+		writeJSON(w, 404, "not found", nil)
+	}
+}
+
+// handleOfflineDownload 添加离线下载（每个地址创建一个等待中的 offline_download 任务）
+func (s *Server) handleOfflineDownload(w http.ResponseWriter, r *http.Request) {
+	var req openlist.OfflineDownloadRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Tool == "" {
+		writeJSON(w, 400, "tool is required", nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[cleanPath(req.Path)]; !ok || !e.isDir {
+		writeJSON(w, 500, "failed get storage: object not found", nil)
+		return
+	}
+	if s.tasks == nil {
+		s.tasks = map[string]*fakeTask{}
+	}
+	tasks := make([]openlist.TaskInfo, 0, len(req.URLs))
+	for _, u := range req.URLs {
+		s.taskSeq++
+		info := openlist.TaskInfo{
+			ID:      fmt.Sprintf("offline-%d", s.taskSeq),
+			Name:    fmt.Sprintf("download %s to (%s)", u, cleanPath(req.Path)),
+			Creator: s.Username,
+			State:   openlist.TaskPending,
+		}
+		s.tasks[info.ID] = &fakeTask{taskType: "offline_download", info: info}
+		tasks = append(tasks, info)
+	}
+	writeJSON(w, 200, "success", openlist.OfflineDownloadResponse{Tasks: tasks})
+}
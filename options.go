@@ -0,0 +1,24 @@
+package openlist
+
+import "net/http"
+
+// Option 客户端配置选项（用于 NewOpenListAPI）
+type Option func(*OpenListAPI)
+
+// WithPasswordProvider 设置受保护目录的密码提供者
+// 列目录、获取文件信息、搜索、下载和压缩包操作时按路径查询密码
+func WithPasswordProvider(provider PasswordProvider) Option {
+	return func(c *OpenListAPI) {
+		c.passwords = provider
+	}
+}
+
+// WithHTTPClient 使用自定义HTTP客户端（如自定义Transport、录制/回放包装器）
+// 自定义客户端已设置 Transport 时，proxy 参数不再生效
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *OpenListAPI) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package openlist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPasswordRequired 访问受保护目录需要密码，但未提供
+	ErrPasswordRequired = errors.New("访问该路径需要密码")
+	// ErrPasswordIncorrect 提供的目录密码不正确（或无权访问）
+	ErrPasswordIncorrect = errors.New("目录密码错误或无权访问")
+)
+
+// PasswordProvider 目录密码提供者
+type PasswordProvider interface {
+	// Password 返回访问指定远程路径所需的密码（无密码时返回空字符串）
+	Password(path string) string
+}
+
+// PasswordFunc 函数形式的密码提供者
+type PasswordFunc func(path string) string
+
+// Password 实现PasswordProvider接口
+func (f PasswordFunc) Password(path string) string {
+	return f(path)
+}
+
+// StaticPasswords 静态密码表（路径前缀 → 密码），按最长前缀匹配
+// 例: StaticPasswords{"/private": "123", "/private/vip": "456"}
+type StaticPasswords map[string]string
+
+// Password 实现PasswordProvider接口
+func (p StaticPasswords) Password(path string) string {
+	best := -1
+	password := ""
+	for prefix, pwd := range p {
+		if !hasPathPrefix(path, prefix) || len(prefix) <= best {
+			continue
+		}
+		best = len(prefix)
+		password = pwd
+	}
+	return password
+}
+
+// hasPathPrefix 判断 path 是否位于 prefix 目录下（按路径段匹配，"/ab" 不匹配前缀 "/a"）
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// pathPassword 查询远程路径的访问密码
+func (c *OpenListAPI) pathPassword(path string) string {
+	if c.passwords == nil {
+		return ""
+	}
+	return c.passwords.Password(path)
+}
+
+// passwordError 将服务端的密码校验失败转换为 ErrPasswordRequired / ErrPasswordIncorrect
+// 其他错误原样返回
+func passwordError(path, password string, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 403 || !strings.Contains(strings.ToLower(apiErr.Message), "password") {
+		return err
+	}
+	if password == "" {
+		return fmt.Errorf("%w (路径: %s): %w", ErrPasswordRequired, path, err)
+	}
+	return fmt.Errorf("%w (路径: %s): %w", ErrPasswordIncorrect, path, err)
+}
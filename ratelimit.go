@@ -0,0 +1,182 @@
+package openlist
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit 限流配置（零值表示不限制）
+type Limit struct {
+	RPS         float64 // 每秒请求数（令牌桶速率，<=0 表示不限速）
+	Burst       int     // 令牌桶容量（<=0 时为1）
+	MaxInFlight int     // 最大并发请求数（<=0 表示不限制）
+}
+
+// RateLimits 客户端限流配置（同一客户端的所有调用方共享）
+type RateLimits struct {
+	Global   Limit            // 全局限制（所有请求）
+	Metadata Limit            // 元数据请求限制（列目录、获取信息、搜索、管理接口等）
+	Transfer Limit            // 传输请求限制（上传、下载）
+	Storages map[string]Limit // 按存储驱动覆盖分类限制（键为 ListResponse.Provider，如 "115 Cloud"）
+}
+
+// WithRateLimits 设置客户端限流（令牌桶限速 + 最大并发）
+// 请求先经过全局限制，再经过所在存储的限制（已知存储驱动且配置了覆盖时）或所属分类的限制；
+// 存储驱动通过列目录、获取文件信息的响应按挂载根目录（路径第一级）自动识别
+//
+// 并发名额: 元数据请求在收到响应头后立即归还；传输请求（上传、下载）在响应体关闭后归还。
+// 因此持有未关闭的下载流（如 OpenArchiveFile 返回的 io.ReadCloser）时会一直占用全局和传输名额，
+// Global.MaxInFlight 或 Transfer.MaxInFlight 为1时，在关闭该流之前发起的其他请求会一直等待
+func WithRateLimits(limits RateLimits) Option {
+	return func(c *OpenListAPI) {
+		c.limits = newRateLimiter(limits)
+	}
+}
+
+// limiter 单个限流器（令牌桶 + 并发信号量）
+type limiter struct {
+	bucket *rate.Limiter // 令牌桶（nil 表示不限速）
+	slots  chan struct{} // 并发名额（nil 表示不限制）
+}
+
+// newLimiter 根据配置创建限流器（不限制时返回nil）
+func newLimiter(l Limit) *limiter {
+	if l.RPS <= 0 && l.MaxInFlight <= 0 {
+		return nil
+	}
+
+	lim := &limiter{}
+	if l.RPS > 0 {
+		lim.bucket = rate.NewLimiter(rate.Limit(l.RPS), max(l.Burst, 1))
+	}
+	if l.MaxInFlight > 0 {
+		lim.slots = make(chan struct{}, l.MaxInFlight)
+	}
+	return lim
+}
+
+// acquire 获取并发名额并等待令牌
+func (l *limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			l.release()
+			return err
+		}
+	}
+	return nil
+}
+
+// release 归还并发名额
+func (l *limiter) release() {
+	if l != nil && l.slots != nil {
+		<-l.slots
+	}
+}
+
+// rateLimiter 客户端限流（全局、分类、按存储）
+type rateLimiter struct {
+	global   *limiter
+	metadata *limiter
+	transfer *limiter
+	storages map[string]*limiter
+
+	mu        sync.RWMutex
+	providers map[string]string // 挂载根目录 → 存储驱动（由响应学习，最多 maxLearnedProviders 条）
+}
+
+// maxLearnedProviders 最多记录的挂载根目录数量
+const maxLearnedProviders = 1024
+
+// newRateLimiter 创建客户端限流
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	r := &rateLimiter{
+		global:    newLimiter(limits.Global),
+		metadata:  newLimiter(limits.Metadata),
+		transfer:  newLimiter(limits.Transfer),
+		storages:  map[string]*limiter{},
+		providers: map[string]string{},
+	}
+	for provider, limit := range limits.Storages {
+		if lim := newLimiter(limit); lim != nil {
+			r.storages[provider] = lim
+		}
+	}
+	return r
+}
+
+// acquire 按操作获取所有适用的限流器
+// 返回值: 请求结束后调用的释放函数，错误信息（上下文取消）
+func (r *rateLimiter) acquire(ctx context.Context, op Operation) (func(), error) {
+	if r == nil {
+		return func() {}, nil
+	}
+
+	scoped := r.metadata
+	if isTransfer(op.Name) {
+		scoped = r.transfer
+	}
+	if storage, ok := r.storages[r.provider(op.Path)]; ok {
+		scoped = storage
+	}
+
+	if err := r.global.acquire(ctx); err != nil {
+		return nil, err
+	}
+	if err := scoped.acquire(ctx); err != nil {
+		r.global.release()
+		return nil, err
+	}
+	return func() {
+		scoped.release()
+		r.global.release()
+	}, nil
+}
+
+// learn 记录路径所在挂载根目录的存储驱动
+func (r *rateLimiter) learn(p, provider string) {
+	if r == nil || p == "" || provider == "" {
+		return
+	}
+
+	root := mountRoot(p)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[root]; ok || len(r.providers) < maxLearnedProviders {
+		r.providers[root] = provider
+	}
+}
+
+// provider 查找路径所在挂载根目录的存储驱动（未知时返回空）
+func (r *rateLimiter) provider(p string) string {
+	if p == "" {
+		return ""
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.providers[mountRoot(p)]
+}
+
+// mountRoot 路径的第一级目录（如 "/docs/sub/a.txt" → "/docs"）
+func mountRoot(p string) string {
+	p = path.Clean("/" + p)
+	if i := strings.Index(p[1:], "/"); i >= 0 {
+		return p[:i+1]
+	}
+	return p
+}
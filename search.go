@@ -0,0 +1,81 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// SearchScope 搜索范围
+type SearchScope int
+
+const (
+	SearchScopeAll     SearchScope = iota // 文件和文件夹
+	SearchScopeFolders                    // 仅文件夹
+	SearchScopeFiles                      // 仅文件
+)
+
+// FullPath 获取搜索结果的完整路径（如 "/docs/test.txt"）
+func (item *SearchItem) FullPath() string {
+	return path.Join("/", item.Parent, item.Name)
+}
+
+// IndexService 搜索索引管理，对应 /api/admin/index/*
+type IndexService struct {
+	api *OpenListAPI
+}
+
+// Index 获取搜索索引管理客户端（需要管理员权限）
+func (c *OpenListAPI) Index() IndexAdmin {
+	return &IndexService{api: c}
+}
+
+// Build 重建搜索索引
+// paths: 需要索引的路径（为空表示全部）
+// maxDepth: 最大深度（-1表示不限制）
+func (s *IndexService) Build(ctx context.Context, paths []string, maxDepth int) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/index/build", nil,
+		IndexRequest{Paths: paths, MaxDepth: maxDepth}, nil); err != nil {
+		return fmt.Errorf("构建索引失败: %w", err)
+	}
+	return nil
+}
+
+// Update 增量更新指定路径的索引（如批量上传后）
+// paths: 需要更新的路径
+// maxDepth: 最大深度（-1表示不限制）
+func (s *IndexService) Update(ctx context.Context, paths []string, maxDepth int) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("更新索引的路径不能为空")
+	}
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/index/update", nil,
+		IndexRequest{Paths: paths, MaxDepth: maxDepth}, nil); err != nil {
+		return fmt.Errorf("更新索引失败: %w", err)
+	}
+	return nil
+}
+
+// Stop 停止正在进行的索引任务
+func (s *IndexService) Stop(ctx context.Context) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/index/stop", nil, nil, nil); err != nil {
+		return fmt.Errorf("停止索引失败: %w", err)
+	}
+	return nil
+}
+
+// Clear 清空搜索索引
+func (s *IndexService) Clear(ctx context.Context) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/index/clear", nil, nil, nil); err != nil {
+		return fmt.Errorf("清空索引失败: %w", err)
+	}
+	return nil
+}
+
+// Progress 获取索引进度
+func (s *IndexService) Progress(ctx context.Context) (*IndexProgress, error) {
+	progress := &IndexProgress{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/index/progress", nil, nil, progress); err != nil {
+		return nil, fmt.Errorf("获取索引进度失败: %w", err)
+	}
+	return progress, nil
+}
@@ -0,0 +1,104 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// 设置项标记
+const (
+	SettingFlagPublic     = 0 // 公开
+	SettingFlagPrivate    = 1 // 私有
+	SettingFlagReadonly   = 2 // 只读
+	SettingFlagDeprecated = 3 // 已废弃
+)
+
+// SettingService 站点设置管理，对应 /api/admin/setting/*
+type SettingService struct {
+	api *OpenListAPI
+}
+
+// Settings 获取站点设置管理客户端（需要管理员权限）
+func (c *OpenListAPI) Settings() SettingAdmin {
+	return &SettingService{api: c}
+}
+
+// List 列出设置项
+// group: 设置分组（0表示全部）
+func (s *SettingService) List(ctx context.Context, group int) ([]SettingItem, error) {
+	var query url.Values
+	if group > 0 {
+		query = url.Values{"group": {fmt.Sprintf("%d", group)}}
+	}
+
+	var settings []SettingItem
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/setting/list", query, nil, &settings); err != nil {
+		return nil, fmt.Errorf("列出设置失败: %w", err)
+	}
+	return settings, nil
+}
+
+// Get 获取单个设置项
+func (s *SettingService) Get(ctx context.Context, key string) (*SettingItem, error) {
+	setting := &SettingItem{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/setting/get", url.Values{"key": {key}}, nil, setting); err != nil {
+		return nil, fmt.Errorf("获取设置失败: %w", err)
+	}
+	return setting, nil
+}
+
+// Save 批量保存设置项
+func (s *SettingService) Save(ctx context.Context, settings []SettingItem) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/save", nil, settings, nil); err != nil {
+		return fmt.Errorf("保存设置失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除设置项
+func (s *SettingService) Delete(ctx context.Context, key string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/delete", url.Values{"key": {key}}, nil, nil); err != nil {
+		return fmt.Errorf("删除设置失败: %w", err)
+	}
+	return nil
+}
+
+// ResetToken 重置站点令牌（同时使所有签名链接失效）
+// 返回值: 新令牌，错误信息
+func (s *SettingService) ResetToken(ctx context.Context) (string, error) {
+	var token string
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/reset_token", nil, nil, &token); err != nil {
+		return "", fmt.Errorf("重置令牌失败: %w", err)
+	}
+	return token, nil
+}
+
+// SetAria2 设置aria2离线下载
+// 返回值: aria2版本号，错误信息
+func (s *SettingService) SetAria2(ctx context.Context, uri, secret string) (string, error) {
+	var version string
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/set_aria2", nil,
+		SetAria2Request{URI: uri, Secret: secret}, &version); err != nil {
+		return "", fmt.Errorf("设置aria2失败: %w", err)
+	}
+	return version, nil
+}
+
+// SetQbit 设置qBittorrent离线下载
+func (s *SettingService) SetQbit(ctx context.Context, webURL, seedtime string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/set_qbit", nil,
+		SetQbitRequest{URL: webURL, Seedtime: seedtime}, nil); err != nil {
+		return fmt.Errorf("设置qBittorrent失败: %w", err)
+	}
+	return nil
+}
+
+// SetTransmission 设置Transmission离线下载
+func (s *SettingService) SetTransmission(ctx context.Context, uri, seedtime string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/setting/set_transmission", nil,
+		SetTransmissionRequest{URI: uri, Seedtime: seedtime}, nil); err != nil {
+		return fmt.Errorf("设置Transmission失败: %w", err)
+	}
+	return nil
+}
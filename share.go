@@ -0,0 +1,94 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ShareService 分享链接管理，对应 /api/share/*
+type ShareService struct {
+	api *OpenListAPI
+}
+
+// Shares 获取分享链接管理客户端
+func (c *OpenListAPI) Shares() ShareManager {
+	return &ShareService{api: c}
+}
+
+// shareQuery 构造分享ID查询参数
+func shareQuery(id string) url.Values {
+	return url.Values{"id": {id}}
+}
+
+// List 列出当前用户的分享
+// page: 页码（默认 1）
+// perPage: 每页条数（0表示不分页）
+func (s *ShareService) List(ctx context.Context, page, perPage int) (*ShareListResponse, error) {
+	listResp := &ShareListResponse{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/share/list", pageQuery(page, perPage), nil, listResp); err != nil {
+		return nil, fmt.Errorf("列出分享失败: %w", err)
+	}
+	return listResp, nil
+}
+
+// Get 获取单个分享
+func (s *ShareService) Get(ctx context.Context, id string) (*Share, error) {
+	share := &Share{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/share/get", shareQuery(id), nil, share); err != nil {
+		return nil, fmt.Errorf("获取分享失败: %w", err)
+	}
+	return share, nil
+}
+
+// Create 创建分享（Files 必填）
+// 返回值: 创建后的分享（含ID），错误信息
+func (s *ShareService) Create(ctx context.Context, share *Share) (*Share, error) {
+	if len(share.Files) == 0 {
+		return nil, fmt.Errorf("分享的文件不能为空")
+	}
+
+	created := &Share{}
+	if err := s.api.adminRequest(ctx, "POST", "/api/share/create", nil, share, created); err != nil {
+		return nil, fmt.Errorf("创建分享失败: %w", err)
+	}
+	return created, nil
+}
+
+// Update 更新分享（share.ID 必须有效）
+func (s *ShareService) Update(ctx context.Context, share *Share) (*Share, error) {
+	updated := &Share{}
+	if err := s.api.adminRequest(ctx, "POST", "/api/share/update", nil, share, updated); err != nil {
+		return nil, fmt.Errorf("更新分享失败: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete 删除分享
+func (s *ShareService) Delete(ctx context.Context, id string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/share/delete", shareQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("删除分享失败: %w", err)
+	}
+	return nil
+}
+
+// Enable 启用分享
+func (s *ShareService) Enable(ctx context.Context, id string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/share/enable", shareQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("启用分享失败: %w", err)
+	}
+	return nil
+}
+
+// Disable 禁用分享
+func (s *ShareService) Disable(ctx context.Context, id string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/share/disable", shareQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("禁用分享失败: %w", err)
+	}
+	return nil
+}
+
+// URL 构造分享的公开访问地址（如 http://localhost:5244/@s/abc123）
+func (s *ShareService) URL(id string) string {
+	return fmt.Sprintf("%s/@s/%s", s.api.baseURL, url.PathEscape(id))
+}
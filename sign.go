@@ -0,0 +1,75 @@
+package openlist
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WithSignSecret 设置服务端签名密钥（站点设置中的 token），用于在客户端直接生成签名下载链接
+func WithSignSecret(secret string) Option {
+	return func(c *OpenListAPI) {
+		c.signSecret = secret
+	}
+}
+
+// SignPath 在客户端计算路径签名（与服务端 HMAC-SHA256 签名算法一致）
+// secret: 服务端签名密钥
+// path: 远程文件路径（如 "/docs/test.txt"）
+// expire: 过期时间（零值表示永不过期，需服务端"链接过期时间"设置为0）
+// 返回值: 签名字符串（可直接作为 sign 查询参数）
+func SignPath(secret, path string, expire time.Time) string {
+	var expireTimestamp int64
+	if !expire.IsZero() {
+		expireTimestamp = expire.Unix()
+	}
+	expireStr := strconv.FormatInt(expireTimestamp, 10)
+
+	h := hmac.New(sha256.New, []byte(secret))
+	io.WriteString(h, path+":"+expireStr)
+	return base64.URLEncoding.EncodeToString(h.Sum(nil)) + ":" + expireStr
+}
+
+// DownloadURL 构造直链下载地址（/d/<path>?sign=...）
+// sign: 文件签名（如 FileInfo.Sign，未启用签名时为空）
+func (c *OpenListAPI) DownloadURL(path, sign string) string {
+	return c.linkURL("/d", path, sign)
+}
+
+// ProxyURL 构造代理下载地址（/p/<path>?sign=...），流量经由OpenList服务中转
+// sign: 文件签名（如 FileInfo.Sign，未启用签名时为空）
+func (c *OpenListAPI) ProxyURL(path, sign string) string {
+	return c.linkURL("/p", path, sign)
+}
+
+// SignedDownloadURL 使用签名密钥在客户端生成直链下载地址（无需请求服务端，适合批量导出链接）
+// expire: 过期时间（零值表示永不过期）
+func (c *OpenListAPI) SignedDownloadURL(path string, expire time.Time) (string, error) {
+	if c.signSecret == "" {
+		return "", fmt.Errorf("未设置签名密钥，请使用 WithSignSecret 配置")
+	}
+	return c.DownloadURL(path, SignPath(c.signSecret, path, expire)), nil
+}
+
+// SignedProxyURL 使用签名密钥在客户端生成代理下载地址
+// expire: 过期时间（零值表示永不过期）
+func (c *OpenListAPI) SignedProxyURL(path string, expire time.Time) (string, error) {
+	if c.signSecret == "" {
+		return "", fmt.Errorf("未设置签名密钥，请使用 WithSignSecret 配置")
+	}
+	return c.ProxyURL(path, SignPath(c.signSecret, path, expire)), nil
+}
+
+// linkURL 构造下载链接
+func (c *OpenListAPI) linkURL(prefix, path, sign string) string {
+	link := c.baseURL + prefix + encodeRemotePath(path)
+	if sign != "" {
+		link += "?" + url.Values{"sign": {sign}}.Encode()
+	}
+	return link
+}
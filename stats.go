@@ -0,0 +1,89 @@
+package openlist
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// 请求结果分类
+const (
+	OutcomeSuccess        = "success"         // 成功
+	OutcomeTransportError = "transport_error" // 网络错误（未收到响应）
+	OutcomeHTTPError      = "http_error"      // HTTP状态码非200
+	OutcomeAPIError       = "api_error"       // 业务状态码非200
+)
+
+// RequestStat 按操作和结果统计的请求数
+type RequestStat struct {
+	Operation string // 操作名称（如 "list"、"upload"）
+	Outcome   string // 结果（OutcomeSuccess 等）
+	Count     int64  // 请求数
+}
+
+// StatsSnapshot 客户端统计快照
+type StatsSnapshot struct {
+	Requests        []RequestStat // 按操作和结果统计的请求数（按操作、结果排序）
+	BytesUploaded   int64         // 上传字节数
+	BytesDownloaded int64         // 下载字节数
+	ActiveTransfers int64         // 进行中的上传/下载数
+	Logins          int64         // 登录次数（含重新登录）
+	Relogins        int64         // 令牌过期后重新登录次数
+	ProxyFallbacks  int64         // 代理地址无效而改为直连的次数
+}
+
+// Stats 客户端运行统计（并发安全，与具体监控系统无关，可导出到 Prometheus 等）
+type Stats struct {
+	mu       sync.Mutex
+	requests map[[2]string]int64
+
+	bytesUploaded   atomic.Int64
+	bytesDownloaded atomic.Int64
+	activeTransfers atomic.Int64
+	logins          atomic.Int64
+	relogins        atomic.Int64
+	proxyFallbacks  atomic.Int64
+}
+
+// Stats 返回客户端运行统计
+func (c *OpenListAPI) Stats() *Stats {
+	return c.stats
+}
+
+// Snapshot 返回当前统计快照
+func (s *Stats) Snapshot() StatsSnapshot {
+	snapshot := StatsSnapshot{
+		BytesUploaded:   s.bytesUploaded.Load(),
+		BytesDownloaded: s.bytesDownloaded.Load(),
+		ActiveTransfers: s.activeTransfers.Load(),
+		Logins:          s.logins.Load(),
+		Relogins:        s.relogins.Load(),
+		ProxyFallbacks:  s.proxyFallbacks.Load(),
+	}
+
+	s.mu.Lock()
+	for key, count := range s.requests {
+		snapshot.Requests = append(snapshot.Requests, RequestStat{Operation: key[0], Outcome: key[1], Count: count})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(snapshot.Requests, func(i, j int) bool {
+		a, b := snapshot.Requests[i], snapshot.Requests[j]
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		return a.Outcome < b.Outcome
+	})
+	return snapshot
+}
+
+// addRequest 记录一次请求结果
+func (s *Stats) addRequest(operation, outcome string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.requests == nil {
+		s.requests = map[[2]string]int64{}
+	}
+	s.requests[[2]string{operation, outcome}]++
+}
@@ -0,0 +1,113 @@
+package openlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AdditionMap 解析驱动附加配置
+func (s *Storage) AdditionMap() (map[string]interface{}, error) {
+	addition := map[string]interface{}{}
+	if s.Addition == "" {
+		return addition, nil
+	}
+	if err := json.Unmarshal([]byte(s.Addition), &addition); err != nil {
+		return nil, fmt.Errorf("解析驱动附加配置失败: %w", err)
+	}
+	return addition, nil
+}
+
+// SetAddition 设置驱动附加配置（接受 map、结构体或 json.RawMessage，序列化为JSON字符串）
+func (s *Storage) SetAddition(addition interface{}) error {
+	if raw, ok := addition.(json.RawMessage); ok {
+		s.Addition = string(raw)
+		return nil
+	}
+	data, err := json.Marshal(addition)
+	if err != nil {
+		return fmt.Errorf("序列化驱动附加配置失败: %w", err)
+	}
+	s.Addition = string(data)
+	return nil
+}
+
+// StorageService 存储（挂载）管理，对应 /api/admin/storage/*
+type StorageService struct {
+	api *OpenListAPI
+}
+
+// Storages 获取存储管理客户端（需要管理员权限）
+func (c *OpenListAPI) Storages() StorageAdmin {
+	return &StorageService{api: c}
+}
+
+// List 列出存储
+// page: 页码（默认 1）
+// perPage: 每页条数（0表示不分页）
+func (s *StorageService) List(ctx context.Context, page, perPage int) (*StorageListResponse, error) {
+	listResp := &StorageListResponse{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/storage/list", pageQuery(page, perPage), nil, listResp); err != nil {
+		return nil, fmt.Errorf("列出存储失败: %w", err)
+	}
+	return listResp, nil
+}
+
+// Get 获取单个存储
+func (s *StorageService) Get(ctx context.Context, id uint) (*Storage, error) {
+	storage := &Storage{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/storage/get", idQuery(id), nil, storage); err != nil {
+		return nil, fmt.Errorf("获取存储失败: %w", err)
+	}
+	return storage, nil
+}
+
+// Create 创建存储
+// 返回值: 新建的存储ID，错误信息
+func (s *StorageService) Create(ctx context.Context, storage *Storage) (uint, error) {
+	createResp := &CreateStorageResponse{}
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/create", nil, storage, createResp); err != nil {
+		return 0, fmt.Errorf("创建存储失败: %w", err)
+	}
+	return createResp.ID, nil
+}
+
+// Update 更新存储（storage.ID 必须有效）
+func (s *StorageService) Update(ctx context.Context, storage *Storage) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/update", nil, storage, nil); err != nil {
+		return fmt.Errorf("更新存储失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除存储
+func (s *StorageService) Delete(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/delete", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("删除存储失败: %w", err)
+	}
+	return nil
+}
+
+// Enable 启用存储
+func (s *StorageService) Enable(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/enable", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("启用存储失败: %w", err)
+	}
+	return nil
+}
+
+// Disable 禁用存储
+func (s *StorageService) Disable(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/disable", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("禁用存储失败: %w", err)
+	}
+	return nil
+}
+
+// LoadAll 重新加载所有存储
+func (s *StorageService) LoadAll(ctx context.Context) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/storage/load_all", nil, nil, nil); err != nil {
+		return fmt.Errorf("重新加载存储失败: %w", err)
+	}
+	return nil
+}
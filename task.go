@@ -0,0 +1,222 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TaskType 后台任务类型（对应 /api/task/{type}/...）
+type TaskType string
+
+const (
+	TaskTypeUpload                  TaskType = "upload"                    // 上传任务
+	TaskTypeCopy                    TaskType = "copy"                      // 复制任务
+	TaskTypeMove                    TaskType = "move"                      // 移动任务
+	TaskTypeDecompress              TaskType = "decompress"                // 解压任务
+	TaskTypeDecompressUpload        TaskType = "decompress_upload"         // 解压后上传任务
+	TaskTypeOfflineDownload         TaskType = "offline_download"          // 离线下载任务
+	TaskTypeOfflineDownloadTransfer TaskType = "offline_download_transfer" // 离线下载转存任务
+)
+
+// TaskState 任务状态
+type TaskState int
+
+const (
+	TaskPending      TaskState = iota // 等待中
+	TaskRunning                       // 运行中
+	TaskSucceeded                     // 已成功
+	TaskCanceling                     // 取消中
+	TaskCanceled                      // 已取消
+	TaskErrored                       // 出错（等待重试）
+	TaskFailing                       // 失败中
+	TaskFailed                        // 已失败
+	TaskWaitingRetry                  // 等待重试
+	TaskBeforeRetry                   // 准备重试
+)
+
+// taskStateNames 任务状态名称
+var taskStateNames = map[TaskState]string{
+	TaskPending:      "pending",
+	TaskRunning:      "running",
+	TaskSucceeded:    "succeeded",
+	TaskCanceling:    "canceling",
+	TaskCanceled:     "canceled",
+	TaskErrored:      "errored",
+	TaskFailing:      "failing",
+	TaskFailed:       "failed",
+	TaskWaitingRetry: "waiting_retry",
+	TaskBeforeRetry:  "before_retry",
+}
+
+// String 实现fmt.Stringer接口
+func (s TaskState) String() string {
+	if name, ok := taskStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+// IsTerminal 是否为终止状态（成功、取消或失败）
+func (s TaskState) IsTerminal() bool {
+	return s == TaskSucceeded || s == TaskCanceled || s == TaskFailed
+}
+
+// defaultTaskPollInterval WaitTask 默认轮询间隔
+const defaultTaskPollInterval = 2 * time.Second
+
+// TaskService 后台任务管理（复制、移动、上传、解压、离线下载等）
+type TaskService struct {
+	api          *OpenListAPI
+	pollInterval time.Duration // WaitTask 轮询间隔（通过 WithTaskPollInterval 设置，默认2秒）
+}
+
+// Tasks 获取后台任务管理客户端
+func (c *OpenListAPI) Tasks() TaskManager {
+	interval := c.taskPollInterval
+	if interval <= 0 {
+		interval = defaultTaskPollInterval
+	}
+	return &TaskService{api: c, pollInterval: interval}
+}
+
+// WithTaskPollInterval 设置 WaitTask 的轮询间隔（默认2秒）
+func WithTaskPollInterval(interval time.Duration) Option {
+	return func(c *OpenListAPI) {
+		c.taskPollInterval = interval
+	}
+}
+
+// taskURL 构造任务接口地址
+func (s *TaskService) taskURL(taskType TaskType, action, taskID string) string {
+	reqURL := fmt.Sprintf("%s/api/task/%s/%s", s.api.baseURL, taskType, action)
+	if taskID != "" {
+		reqURL += "?" + url.Values{"tid": {taskID}}.Encode()
+	}
+	return reqURL
+}
+
+// do 执行任务接口请求
+func (s *TaskService) do(ctx context.Context, method string, taskType TaskType, action, taskID string, result interface{}) error {
+	// 先检查登录状态
+	if err := s.api.ensureLogin("管理后台任务"); err != nil {
+		return err
+	}
+
+	return s.api.doRequestContext(ctx, &HTTPRequest{
+		Method: method,
+		URL:    s.taskURL(taskType, action, taskID),
+	}, result)
+}
+
+// List 列出任务
+// taskType: 任务类型
+// done: true 列出已结束的任务，false 列出未完成的任务
+// 返回值: 任务列表，错误信息
+func (s *TaskService) List(ctx context.Context, taskType TaskType, done bool) ([]TaskInfo, error) {
+	action := "undone"
+	if done {
+		action = "done"
+	}
+
+	var tasks []TaskInfo
+	if err := s.do(ctx, "GET", taskType, action, "", &tasks); err != nil {
+		return nil, fmt.Errorf("列出任务失败: %w", err)
+	}
+	return tasks, nil
+}
+
+// Get 获取单个任务信息
+func (s *TaskService) Get(ctx context.Context, taskType TaskType, taskID string) (*TaskInfo, error) {
+	task := &TaskInfo{}
+	if err := s.do(ctx, "POST", taskType, "info", taskID, task); err != nil {
+		return nil, fmt.Errorf("获取任务信息失败: %w", err)
+	}
+	return task, nil
+}
+
+// Cancel 取消任务
+func (s *TaskService) Cancel(ctx context.Context, taskType TaskType, taskID string) error {
+	if err := s.do(ctx, "POST", taskType, "cancel", taskID, nil); err != nil {
+		return fmt.Errorf("取消任务失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除任务记录
+func (s *TaskService) Delete(ctx context.Context, taskType TaskType, taskID string) error {
+	if err := s.do(ctx, "POST", taskType, "delete", taskID, nil); err != nil {
+		return fmt.Errorf("删除任务失败: %w", err)
+	}
+	return nil
+}
+
+// Retry 重试任务
+func (s *TaskService) Retry(ctx context.Context, taskType TaskType, taskID string) error {
+	if err := s.do(ctx, "POST", taskType, "retry", taskID, nil); err != nil {
+		return fmt.Errorf("重试任务失败: %w", err)
+	}
+	return nil
+}
+
+// RetryFailed 重试所有失败的任务
+func (s *TaskService) RetryFailed(ctx context.Context, taskType TaskType) error {
+	if err := s.do(ctx, "POST", taskType, "retry_failed", "", nil); err != nil {
+		return fmt.Errorf("重试失败任务失败: %w", err)
+	}
+	return nil
+}
+
+// ClearDone 清除所有已结束的任务
+func (s *TaskService) ClearDone(ctx context.Context, taskType TaskType) error {
+	if err := s.do(ctx, "POST", taskType, "clear_done", "", nil); err != nil {
+		return fmt.Errorf("清除已结束任务失败: %w", err)
+	}
+	return nil
+}
+
+// ClearSucceeded 清除所有已成功的任务
+func (s *TaskService) ClearSucceeded(ctx context.Context, taskType TaskType) error {
+	if err := s.do(ctx, "POST", taskType, "clear_succeeded", "", nil); err != nil {
+		return fmt.Errorf("清除已成功任务失败: %w", err)
+	}
+	return nil
+}
+
+// WaitTask 轮询任务直到进入终止状态
+// 任务成功时返回任务信息；失败或被取消时返回任务信息和 *TaskFailedError；
+// ctx 结束时返回最近一次查询到的任务信息（可能为nil）和 ctx.Err()
+func (s *TaskService) WaitTask(ctx context.Context, taskType TaskType, taskID string) (*TaskInfo, error) {
+	interval := s.pollInterval
+	if interval <= 0 {
+		interval = defaultTaskPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *TaskInfo // 最近一次查询到的任务信息
+	for {
+		task, err := s.Get(ctx, taskType, taskID)
+		if err != nil {
+			// 查询过程中 ctx 结束时与等待期间结束保持一致
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		if task.State.IsTerminal() {
+			if task.State != TaskSucceeded {
+				return task, &TaskFailedError{Task: task}
+			}
+			return task, nil
+		}
+		last = task
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
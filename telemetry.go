@@ -0,0 +1,219 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// 埋点名称（Tracer / Meter）
+const instrumentationName = "github.com/littleboss01/openlistClient"
+
+// WithTracerProvider 设置 OpenTelemetry 链路追踪提供者
+// 每次请求创建一个 "openlist.<操作>" 客户端span（如 openlist.list、openlist.upload），
+// 包含路径、存储驱动、HTTP状态码、业务状态码和字节数属性；未设置时不产生span
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *OpenListAPI) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider 设置 OpenTelemetry 指标提供者
+// 记录请求耗时、按错误码统计的错误数、传输字节数和传输速率；未设置时不记录指标
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *OpenListAPI) {
+		c.meterProvider = provider
+	}
+}
+
+// telemetry 链路追踪与指标
+type telemetry struct {
+	tracer      trace.Tracer
+	duration    metric.Float64Histogram // 请求耗时（秒）
+	errors      metric.Int64Counter     // 错误数（按操作和错误码）
+	transferred metric.Int64Counter     // 传输字节数（按操作和方向）
+	throughput  metric.Float64Histogram // 上传/下载速率（字节/秒）
+}
+
+// initTelemetry 根据配置的提供者创建 Tracer 和指标（未配置时使用空实现）
+func (c *OpenListAPI) initTelemetry() {
+	if c.tracerProvider == nil {
+		c.tracerProvider = tracenoop.NewTracerProvider()
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = metricnoop.NewMeterProvider()
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	t := &telemetry{tracer: c.tracerProvider.Tracer(instrumentationName)}
+
+	// 创建失败时返回的仍是可用的空实现，忽略错误
+	t.duration, _ = meter.Float64Histogram("openlist.client.request.duration",
+		metric.WithDescription("OpenList 请求耗时"), metric.WithUnit("s"))
+	t.errors, _ = meter.Int64Counter("openlist.client.request.errors",
+		metric.WithDescription("OpenList 请求错误数"), metric.WithUnit("{error}"))
+	t.transferred, _ = meter.Int64Counter("openlist.client.transfer.bytes",
+		metric.WithDescription("OpenList 传输字节数"), metric.WithUnit("By"))
+	t.throughput, _ = meter.Float64Histogram("openlist.client.transfer.throughput",
+		metric.WithDescription("OpenList 上传/下载速率"), metric.WithUnit("By/s"))
+
+	c.telemetry = t
+}
+
+// isTransfer 判断操作是否为文件传输（上传/下载）
+func isTransfer(name string) bool {
+	return isUpload(name) || strings.HasSuffix(name, "download")
+}
+
+// isUpload 判断操作是否为上传
+func isUpload(name string) bool {
+	return strings.HasPrefix(name, "upload")
+}
+
+// responseInfo JSON响应中的业务信息
+type responseInfo struct {
+	Code     int    // 业务状态码
+	Provider string // 存储驱动（列目录、获取文件信息时返回）
+	ok       bool   // 是否为可解析的JSON响应
+}
+
+// callObserver 记录一次请求的span、指标（响应体关闭时结束）
+type callObserver struct {
+	c     *OpenListAPI
+	ctx   context.Context
+	span  trace.Span
+	op    Operation
+	start time.Time
+	sent  int64
+
+	release func()               // 归还限流并发名额（可选，仅传输请求）
+	log     func(received int64) // 记录请求日志（可选，响应体关闭时调用）
+}
+
+// startCall 开始记录一次请求，返回带span的上下文
+func (c *OpenListAPI) startCall(ctx context.Context, op Operation, sent int64) (context.Context, *callObserver) {
+	ctx, span := c.telemetry.tracer.Start(ctx, "openlist."+op.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("openlist.operation", op.Name),
+			attribute.String("openlist.path", op.Path),
+			attribute.Int("openlist.attempt", op.Attempt),
+		))
+	if isTransfer(op.Name) {
+		c.stats.activeTransfers.Add(1)
+	}
+	return ctx, &callObserver{c: c, ctx: ctx, span: span, op: op, start: time.Now(), sent: max(sent, 0)}
+}
+
+// finish 请求完成（响应头已返回或发送失败）
+// 失败时立即结束span；成功时包装响应体，读取完毕关闭后结束
+func (o *callObserver) finish(resp *http.Response, info responseInfo, err error) {
+	if err != nil {
+		o.end(err, OutcomeTransportError, "transport", 0)
+		return
+	}
+
+	o.span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if info.ok {
+		o.span.SetAttributes(attribute.Int("openlist.code", info.Code))
+	}
+	if info.Provider != "" {
+		o.span.SetAttributes(attribute.String("openlist.provider", info.Provider))
+	}
+
+	var failure error
+	outcome, errorCode := OutcomeSuccess, ""
+	switch {
+	case resp.StatusCode != http.StatusOK:
+		outcome, errorCode = OutcomeHTTPError, fmt.Sprintf("%d", resp.StatusCode)
+		failure = fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
+	case info.ok && info.Code != 200:
+		outcome, errorCode = OutcomeAPIError, fmt.Sprintf("%d", info.Code)
+		failure = fmt.Errorf("业务状态码: %d", info.Code)
+	}
+
+	resp.Body = &observedBody{ReadCloser: resp.Body, onClose: func(received int64) {
+		o.end(failure, outcome, errorCode, received)
+	}}
+}
+
+// end 结束span并记录指标和统计
+func (o *callObserver) end(err error, outcome, errorCode string, received int64) {
+	elapsed := time.Since(o.start)
+	t := o.c.telemetry
+	if o.release != nil {
+		o.release()
+	}
+	if o.log != nil {
+		o.log(received)
+	}
+
+	stats := o.c.stats
+	stats.addRequest(o.op.Name, outcome)
+	if isTransfer(o.op.Name) {
+		stats.activeTransfers.Add(-1)
+		if err == nil && isUpload(o.op.Name) {
+			stats.bytesUploaded.Add(o.sent)
+		} else if err == nil {
+			stats.bytesDownloaded.Add(received)
+		}
+	}
+	opAttr := attribute.String("openlist.operation", o.op.Name)
+
+	o.span.SetAttributes(
+		attribute.Int64("openlist.bytes_sent", o.sent),
+		attribute.Int64("openlist.bytes_received", received),
+	)
+	if err != nil {
+		o.span.RecordError(err)
+		o.span.SetStatus(codes.Error, err.Error())
+		t.errors.Add(o.ctx, 1, metric.WithAttributes(opAttr, attribute.String("openlist.error_code", errorCode)))
+	}
+	o.span.End()
+
+	t.duration.Record(o.ctx, elapsed.Seconds(), metric.WithAttributes(opAttr, attribute.Bool("openlist.success", err == nil)))
+	if o.sent > 0 {
+		t.transferred.Add(o.ctx, o.sent, metric.WithAttributes(opAttr, attribute.String("openlist.direction", "upload")))
+	}
+	if received > 0 {
+		t.transferred.Add(o.ctx, received, metric.WithAttributes(opAttr, attribute.String("openlist.direction", "download")))
+	}
+	if isTransfer(o.op.Name) && err == nil && elapsed > 0 {
+		if bytes := max(o.sent, received); bytes > 0 {
+			t.throughput.Record(o.ctx, float64(bytes)/elapsed.Seconds(), metric.WithAttributes(opAttr))
+		}
+	}
+}
+
+// observedBody 统计已读取字节数的响应体，关闭时回调一次
+type observedBody struct {
+	io.ReadCloser
+	read    int64
+	once    sync.Once
+	onClose func(received int64)
+}
+
+// Read 读取并统计字节数
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// Close 关闭响应体并回调
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.read) })
+	return err
+}
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// archiveFiles 测试用压缩包内容
+var archiveFiles = map[string][]byte{
+	"/readme.md":      []byte("# readme"),
+	"/docs/a.txt":     []byte("aaa"),
+	"/docs/b.txt":     []byte("bbbb"),
+	"/docs/sub/c.txt": []byte("c"),
+}
+
+// TestArchiveMetaAndList 测试压缩包元信息与内部目录列表
+func TestArchiveMetaAndList(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddArchive("/files/data.zip", archiveFiles, "secret")
+	ctx := context.Background()
+
+	meta, err := api.ArchiveMeta(ctx, "/files/data.zip", "secret")
+	if err != nil {
+		t.Fatalf("获取压缩包元信息失败: %v", err)
+	}
+	if !meta.Encrypted || len(meta.Content) != 2 || meta.Content[0].Name != "docs" || !meta.Content[0].IsDir ||
+		len(meta.Content[0].Children) != 3 || meta.Content[1].Name != "readme.md" || meta.Content[1].Size != 8 {
+		t.Fatalf("压缩包元信息不正确: %+v", meta)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/fs/archive/meta"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["path"] != "/files/data.zip" || sent["archive_pass"] != "secret" {
+		t.Fatalf("元信息请求体不正确: %v", sent)
+	}
+
+	// 压缩包密码错误
+	if _, err := api.ArchiveMeta(ctx, "/files/data.zip", "wrong"); err == nil {
+		t.Fatal("压缩包密码错误时应返回错误")
+	}
+
+	// 列出内部目录
+	list, err := api.ArchiveList(ctx, "/files/data.zip", "/docs", "secret", 1, 0)
+	if err != nil {
+		t.Fatalf("列出压缩包目录失败: %v", err)
+	}
+	assertArchiveNames(t, list.Content, "a.txt", "b.txt", "sub")
+	if err := json.Unmarshal(server.LastBody("/api/fs/archive/list"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["inner_path"] != "/docs" || sent["archive_pass"] != "secret" || sent["path"] != "/files/data.zip" {
+		t.Fatalf("列表请求体不正确: %v", sent)
+	}
+
+	// 内部路径为空时默认列出根目录，并支持分页
+	list, err = api.ArchiveList(ctx, "/files/data.zip", "", "secret", 2, 1)
+	if err != nil {
+		t.Fatalf("列出压缩包根目录失败: %v", err)
+	}
+	if list.Total != 2 {
+		t.Fatalf("压缩包根目录总数应为2, 实际为 %d", list.Total)
+	}
+	assertArchiveNames(t, list.Content, "readme.md")
+	if err := json.Unmarshal(server.LastBody("/api/fs/archive/list"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["inner_path"] != "/" {
+		t.Fatalf("默认内部路径应为 /, 实际为 %v", sent["inner_path"])
+	}
+
+	if _, err := api.ArchiveList(ctx, "/files/data.zip", "/docs", "", 1, 0); err == nil {
+		t.Fatal("缺少压缩包密码时应返回错误")
+	}
+}
+
+// assertArchiveNames 断言压缩包目录列表的名称
+func assertArchiveNames(t *testing.T, content []openlist.FileInfo, names ...string) {
+	t.Helper()
+	got := make([]string, 0, len(content))
+	for _, item := range content {
+		got = append(got, item.Name)
+	}
+	if !slices.Equal(got, names) {
+		t.Fatalf("压缩包目录内容应为 %v, 实际为 %v", names, got)
+	}
+}
+
+// TestOpenArchiveFile 测试读取压缩包内文件
+func TestOpenArchiveFile(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddArchive("/files/data.zip", archiveFiles, "secret")
+	ctx := context.Background()
+
+	rc, err := api.OpenArchiveFile(ctx, "/files/data.zip", "/docs/sub/c.txt", "secret")
+	if err != nil {
+		t.Fatalf("打开压缩包内文件失败: %v", err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "c" {
+		t.Fatalf("压缩包内文件内容不正确: %q, %v", data, err)
+	}
+
+	if _, err := api.OpenArchiveFile(ctx, "/files/data.zip", "/docs/missing.txt", "secret"); err == nil {
+		t.Fatal("压缩包内文件不存在时应返回错误")
+	}
+	if _, err := api.OpenArchiveFile(ctx, "/files/data.zip", "/readme.md", "wrong"); err == nil {
+		t.Fatal("压缩包密码错误时应返回错误")
+	}
+}
+
+// TestDecompress 测试解压的请求参数、解压结果与返回的任务
+func TestDecompress(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddArchive("/files/data.zip", archiveFiles, "secret")
+	server.AddDir("/out")
+	ctx := context.Background()
+
+	tasks, err := api.Decompress(ctx, openlist.DecompressRequest{
+		SrcDir:        "/files",
+		DstDir:        "/out",
+		Name:          []string{"data.zip"},
+		ArchivePass:   "secret",
+		InnerPath:     "/docs",
+		PutIntoNewDir: true,
+	})
+	if err != nil {
+		t.Fatalf("解压失败: %v", err)
+	}
+
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/fs/archive/decompress"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	names, _ := sent["name"].([]any)
+	if sent["src_dir"] != "/files" || sent["dst_dir"] != "/out" || len(names) != 1 || names[0] != "data.zip" ||
+		sent["archive_pass"] != "secret" || sent["inner_path"] != "/docs" || sent["put_into_new_dir"] != true {
+		t.Fatalf("解压请求体不正确: %v", sent)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID == "" {
+		t.Fatalf("返回的任务不正确: %+v", tasks)
+	}
+	task, err := api.Tasks().Get(ctx, openlist.TaskTypeDecompress, tasks[0].ID)
+	if err != nil || task.State != openlist.TaskSucceeded {
+		t.Fatalf("查询解压任务失败: %+v, %v", task, err)
+	}
+
+	// 仅解压内部路径下的文件
+	for _, p := range []string{"/out/data/a.txt", "/out/data/b.txt", "/out/data/sub/c.txt"} {
+		if !server.Exists(p) {
+			t.Errorf("解压后 %s 应存在", p)
+		}
+	}
+	if server.Exists("/out/data/readme.md") {
+		t.Error("内部路径之外的文件不应被解压")
+	}
+	if data, _ := server.ReadFile("/out/data/sub/c.txt"); string(data) != "c" {
+		t.Errorf("解压后的文件内容不正确: %q", data)
+	}
+
+	// 内部路径为空时默认解压全部，密码错误时返回错误
+	if _, err := api.Decompress(ctx, openlist.DecompressRequest{SrcDir: "/files", DstDir: "/out", Name: []string{"data.zip"}}); err == nil {
+		t.Fatal("缺少压缩包密码时应返回错误")
+	}
+	if err := json.Unmarshal(server.LastBody("/api/fs/archive/decompress"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["inner_path"] != "/" {
+		t.Fatalf("默认内部路径应为 /, 实际为 %v", sent["inner_path"])
+	}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestBandwidthLimits 测试上传、下载带宽限制及运行时调整
+func TestBandwidthLimits(t *testing.T) {
+	api, server := newTestClient(t, openlist.WithBandwidthLimits(32*1024, 64*1024))
+	server.AddFile("/docs/big.bin", make([]byte, 96*1024))
+
+	// 下载：64KB/s，首个令牌桶容量64KB立即可用，其余32KB约0.5秒
+	var lastProgress int64
+	start := time.Now()
+	err := api.DownloadFile("/docs/big.bin", filepath.Join(t.TempDir(), "big.bin"), func(downloaded, total int64) {
+		lastProgress = downloaded
+	})
+	if err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("下载限速未生效，耗时 %v", elapsed)
+	}
+	if lastProgress != 96*1024 {
+		t.Errorf("下载进度不正确: %d", lastProgress)
+	}
+
+	// 上传：32KB/s，48KB约0.5秒
+	data := make([]byte, 48*1024)
+	start = time.Now()
+	if _, err := api.UploadStream(bytes.NewReader(data), int64(len(data)), "/docs/up.bin"); err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("上传限速未生效，耗时 %v", elapsed)
+	}
+
+	// 运行时取消限制
+	api.SetUploadLimit(0)
+	start = time.Now()
+	if _, err := api.UploadStream(bytes.NewReader(data), int64(len(data)), "/docs/up2.bin"); err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("取消限速后上传过慢，耗时 %v", elapsed)
+	}
+	if content, ok := server.ReadFile("/docs/up2.bin"); !ok || len(content) != len(data) {
+		t.Errorf("上传内容不正确: %d", len(content))
+	}
+}
+
+// TestBandwidthTimeout 测试限速传输耗时超过HTTP客户端超时仍能完成，并可通过上下文取消
+func TestBandwidthTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: 200 * time.Millisecond}
+	api, server := newTestClient(t,
+		openlist.WithHTTPClient(httpClient),
+		openlist.WithBandwidthLimits(32*1024, 32*1024))
+	server.AddFile("/docs/big.bin", make([]byte, 64*1024))
+
+	// 下载：32KB/s，64KB约1秒，超过客户端的200毫秒超时
+	localPath := filepath.Join(t.TempDir(), "big.bin")
+	if err := api.DownloadFile("/docs/big.bin", localPath, nil); err != nil {
+		t.Fatalf("限速下载不应受客户端超时限制: %v", err)
+	}
+
+	// 表单上传
+	if _, err := api.UploadFile(localPath, "/up"); err != nil {
+		t.Fatalf("限速上传不应受客户端超时限制: %v", err)
+	}
+	if content, ok := server.ReadFile("/up/big.bin"); !ok || len(content) != 64*1024 {
+		t.Errorf("上传内容不正确: %d", len(content))
+	}
+
+	// 通过上下文限制总耗时
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := api.DownloadFile("/docs/big.bin", localPath, nil, openlist.WithContext(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("下载应因上下文超时失败，实际: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestConfigBundle 测试配置导出与幂等导入
+func TestConfigBundle(t *testing.T) {
+	ctx := context.Background()
+	source, sourceServer := newTestClient(t)
+	sourceServer.AddSetting(openlist.SettingItem{Key: "site_title", Value: "Source", Type: "string"})
+	sourceServer.AddSetting(openlist.SettingItem{Key: "token", Value: "secret-token", Flag: 1})
+	sourceServer.AddStorage(openlist.Storage{MountPath: "/local", Driver: "Local", Addition: `{"root_folder_path":"/data"}`})
+	sourceServer.AddMeta(openlist.Meta{Path: "/local/private", Password: "meta-pass", PSub: true})
+	sourceServer.AddUser(openlist.User{Username: "alice", Password: "alice-pass", BasePath: "/local", Role: openlist.RoleGeneral})
+	sourceServer.AddUser(openlist.User{Username: "bob", Password: "bob-pass", BasePath: "/", Role: openlist.RoleGeneral})
+
+	bundle, err := source.ExportConfig(ctx)
+	if err != nil {
+		t.Fatalf("导出配置失败: %v", err)
+	}
+	if len(bundle.Users) != 2 {
+		t.Fatalf("导出的用户应不含管理员和访客: %+v", bundle.Users)
+	}
+	for _, user := range bundle.Users {
+		if user.Password != "" {
+			t.Fatalf("导出的用户不应包含密码: %+v", user)
+		}
+	}
+	for _, setting := range bundle.Settings {
+		if setting.Key == "token" {
+			t.Fatal("导出的设置不应包含令牌")
+		}
+	}
+
+	// 1. 首次导入：新建存储、元信息和用户
+	target, targetServer := newTestClient(t)
+	report, err := target.ImportConfig(ctx, bundle, openlist.WithUserPasswords(map[string]string{"alice": "alice-new"}))
+	if err != nil {
+		t.Fatalf("导入配置失败: %v", err)
+	}
+	if report.StoragesCreated != 1 || report.MetasCreated != 1 || report.UsersCreated != 2 {
+		t.Fatalf("首次导入统计不正确: %+v", report)
+	}
+	if _, ok := report.GeneratedPasswords["alice"]; ok || report.GeneratedPasswords["bob"] == "" {
+		t.Fatalf("应仅为未指定密码的用户生成随机密码: %+v", report.GeneratedPasswords)
+	}
+	passwords := map[string]string{}
+	for _, user := range targetServer.Users() {
+		passwords[user.Username] = user.Password
+	}
+	if passwords["alice"] != "alice-new" || passwords["bob"] != report.GeneratedPasswords["bob"] {
+		t.Fatalf("新建用户的密码不正确: %+v", passwords)
+	}
+
+	// 2. 再次导入：全部跳过，且不产生重复项
+	report, err = target.ImportConfig(ctx, bundle)
+	if err != nil {
+		t.Fatalf("再次导入配置失败: %v", err)
+	}
+	if report.StoragesCreated+report.StoragesUpdated+report.MetasCreated+report.MetasUpdated+
+		report.UsersCreated+report.UsersUpdated != 0 || len(report.GeneratedPasswords) != 0 {
+		t.Fatalf("再次导入应无变化: %+v", report)
+	}
+	if report.StoragesSkipped != 1 || report.MetasSkipped != 1 || report.UsersSkipped != 2 {
+		t.Fatalf("再次导入统计不正确: %+v", report)
+	}
+	if len(targetServer.Storages()) != 1 || len(targetServer.Metas()) != 1 || len(targetServer.Users()) != 4 {
+		t.Fatalf("导入后出现重复项: storages=%d metas=%d users=%d",
+			len(targetServer.Storages()), len(targetServer.Metas()), len(targetServer.Users()))
+	}
+	for _, user := range targetServer.Users() {
+		if user.Username == "bob" && user.Password != passwords["bob"] {
+			t.Fatal("再次导入不应修改已有用户的密码")
+		}
+	}
+
+	// 3. 快照中的管理员和访客会被跳过
+	bundle.Users = append(bundle.Users,
+		openlist.User{Username: targetServer.Username, Role: openlist.RoleAdmin},
+		openlist.User{Username: "guest", Role: openlist.RoleGuest})
+	report, err = target.ImportConfig(ctx, bundle)
+	if err != nil {
+		t.Fatalf("导入含内置用户的配置失败: %v", err)
+	}
+	if report.UsersSkipped != 4 || report.UsersCreated+report.UsersUpdated != 0 {
+		t.Fatalf("内置用户应被跳过: %+v", report)
+	}
+}
+
+// TestConfigBundleYAML 测试配置快照的 YAML 读写
+func TestConfigBundleYAML(t *testing.T) {
+	bundle := &openlist.ConfigBundle{
+		Version:  openlist.ConfigBundleVersion,
+		Settings: []openlist.SettingItem{{Key: "site_title", Value: "Demo"}},
+		Storages: []openlist.Storage{{MountPath: "/local", Driver: "Local", Addition: `{"root_folder_path":"/data"}`}},
+		Users:    []openlist.User{{Username: "alice", BasePath: "/", Permission: 3}},
+	}
+
+	var buf bytes.Buffer
+	if err := bundle.WriteYAML(&buf); err != nil {
+		t.Fatalf("写入YAML失败: %v", err)
+	}
+	if !strings.Contains(buf.String(), "mount_path: /local") {
+		t.Fatalf("YAML应使用JSON字段名:\n%s", buf.String())
+	}
+
+	read, err := openlist.ReadConfigBundle(&buf)
+	if err != nil {
+		t.Fatalf("读取YAML失败: %v", err)
+	}
+	if read.Storages[0].Addition != bundle.Storages[0].Addition || read.Users[0].Permission != 3 ||
+		read.Settings[0].Value != "Demo" {
+		t.Fatalf("YAML往返结果不一致: %+v", read)
+	}
+
+	// JSON 同样可读
+	buf.Reset()
+	if err := bundle.WriteJSON(&buf); err != nil {
+		t.Fatalf("写入JSON失败: %v", err)
+	}
+	if _, err := openlist.ReadConfigBundle(&buf); err != nil {
+		t.Fatalf("读取JSON失败: %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestCache 测试元数据缓存命中与写操作后的失效
+func TestCache(t *testing.T) {
+	api, server := newTestClient(t, openlist.WithCache(openlist.NewMemoryCache(time.Minute, 100)))
+	server.AddFile("/docs/a.txt", []byte("hello"))
+
+	// 重复列目录、获取信息只请求一次
+	for i := 0; i < 3; i++ {
+		if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+			t.Fatalf("列目录失败: %v", err)
+		}
+		if _, err := api.GetFileInfo("/docs/a.txt"); err != nil {
+			t.Fatalf("获取文件信息失败: %v", err)
+		}
+	}
+	if server.Calls("/api/fs/list") != 1 || server.Calls("/api/fs/get") != 1 {
+		t.Errorf("缓存未命中: list=%d get=%d", server.Calls("/api/fs/list"), server.Calls("/api/fs/get"))
+	}
+
+	// 强制刷新绕过缓存
+	if _, err := api.ListFiles("/docs", 1, 0, true); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	if server.Calls("/api/fs/list") != 2 {
+		t.Errorf("强制刷新应请求服务端")
+	}
+
+	// 多级目录创建复用已缓存的上级目录
+	if err := api.Mkdirs("/docs/sub/deep"); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+
+	// 上传后所在目录失效
+	if _, err := api.UploadFile(writeTempFile(t, "b.txt", "world"), "/docs"); err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	assertNames(t, api, "/docs", "a.txt", "b.txt", "sub")
+
+	// 重命名后旧路径失效
+	if err := api.Rename("/docs/a.txt", "c.txt"); err != nil {
+		t.Fatalf("重命名失败: %v", err)
+	}
+	if _, err := api.GetFileInfo("/docs/a.txt"); err == nil {
+		t.Error("重命名后不应读取到旧路径缓存")
+	}
+	assertNames(t, api, "/docs", "b.txt", "c.txt", "sub")
+
+	// 移动、删除后源目录与目标目录失效
+	assertNames(t, api, "/docs/sub", "deep")
+	if err := api.Move("/docs", "/docs/sub", []string{"b.txt"}); err != nil {
+		t.Fatalf("移动失败: %v", err)
+	}
+	assertNames(t, api, "/docs/sub", "b.txt", "deep")
+	if err := api.Remove("/docs", []string{"sub"}); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	assertNames(t, api, "/docs", "c.txt")
+	if _, err := api.ListFiles("/docs/sub", 1, 0, false); err == nil {
+		t.Error("删除后不应读取到子目录缓存")
+	}
+}
+
+// assertNames 检查目录内容（不考虑顺序）
+func assertNames(t *testing.T, api *openlist.OpenListAPI, dir string, names ...string) {
+	t.Helper()
+	listResp, err := api.ListFiles(dir, 1, 0, false)
+	if err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	got := map[string]bool{}
+	for _, item := range listResp.Content {
+		got[item.Name] = true
+	}
+	for _, name := range names {
+		if !got[name] {
+			t.Errorf("目录 %s 缺少 %s，实际: %v", dir, name, got)
+		}
+	}
+	if len(got) != len(names) {
+		t.Errorf("目录 %s 内容应为 %v，实际: %v", dir, names, got)
+	}
+}
+
+// TestMemoryCache 测试内存缓存过期与容量淘汰
+func TestMemoryCache(t *testing.T) {
+	cache := openlist.NewMemoryCache(50*time.Millisecond, 2)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Set("c", 3) // 淘汰最久未使用的 b
+	if _, ok := cache.Get("b"); ok {
+		t.Error("超出容量后应淘汰最久未使用的条目")
+	}
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Error("最近使用的条目不应被淘汰")
+	}
+
+	cache.DeletePrefix("c")
+	if _, ok := cache.Get("c"); ok {
+		t.Error("按前缀删除失败")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("过期条目不应返回")
+	}
+}
+
+// switchablePassword 可切换的访问密码
+type switchablePassword struct {
+	password string
+}
+
+func (p *switchablePassword) Password(string) string { return p.password }
+
+// TestCacheEntries 测试缓存条目隔离：不含下载地址、深拷贝哈希、按密码区分
+func TestCacheEntries(t *testing.T) {
+	passwords := &switchablePassword{password: "old"}
+	api, server := newTestClient(t,
+		openlist.WithCache(openlist.NewMemoryCache(time.Minute, 100)),
+		openlist.WithPasswordProvider(passwords))
+	server.AddFile("/docs/a.txt", []byte("hello"))
+
+	first, err := api.GetFileInfo("/docs/a.txt")
+	if err != nil {
+		t.Fatalf("获取文件信息失败: %v", err)
+	}
+	if first.Raw_url == "" {
+		t.Fatal("未命中缓存时应返回下载地址")
+	}
+
+	// 命中缓存时不含可能过期的下载地址；修改返回的哈希不影响缓存
+	first.Hash_info[openlist.HashMD5] = "modified"
+	cached, err := api.GetFileInfo("/docs/a.txt")
+	if err != nil {
+		t.Fatalf("获取文件信息失败: %v", err)
+	}
+	if cached.Raw_url != "" || cached.Sign != "" {
+		t.Errorf("缓存的文件信息不应包含下载地址和签名: %+v", cached)
+	}
+	if cached.Hash_info[openlist.HashMD5] == "modified" {
+		t.Error("修改返回结果的哈希不应影响缓存")
+	}
+	cached.Hash_info[openlist.HashMD5] = "modified"
+	if again, _ := api.GetFileInfo("/docs/a.txt"); again.Hash_info[openlist.HashMD5] == "modified" {
+		t.Error("缓存命中结果应深拷贝哈希")
+	}
+	if got := server.Calls("/api/fs/get"); got != 1 {
+		t.Fatalf("应命中缓存，实际请求 %d 次", got)
+	}
+
+	// 下载时始终获取最新的下载地址
+	if err := api.DownloadFile("/docs/a.txt", filepath.Join(t.TempDir(), "a.txt"), nil); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if got := server.Calls("/api/fs/get"); got != 2 {
+		t.Errorf("下载应绕过缓存，实际请求 %d 次", got)
+	}
+
+	// 更换密码后不使用旧密码的缓存
+	passwords.password = "new"
+	if _, err := api.GetFileInfo("/docs/a.txt"); err != nil {
+		t.Fatalf("获取文件信息失败: %v", err)
+	}
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	passwords.password = "old"
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	if server.Calls("/api/fs/get") != 3 || server.Calls("/api/fs/list") != 2 {
+		t.Errorf("不同密码应分别缓存: get=%d list=%d", server.Calls("/api/fs/get"), server.Calls("/api/fs/list"))
+	}
+}
+
+// TestMemoryCacheDeletePrefix 测试按前缀删除只影响匹配的条目
+func TestMemoryCacheDeletePrefix(t *testing.T) {
+	cache := openlist.NewMemoryCache(0, 0)
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprintf("/docs/%02d|get|", i), i)
+		cache.Set(fmt.Sprintf("/docs2/%02d|get|", i), i)
+	}
+	cache.Set("/docs|list|1|0|", "list")
+
+	cache.DeletePrefix("/docs/")
+	if cache.Len() != 101 {
+		t.Errorf("应剩余101个条目，实际 %d", cache.Len())
+	}
+	if _, ok := cache.Get("/docs/05|get|"); ok {
+		t.Error("匹配前缀的条目应被删除")
+	}
+	if _, ok := cache.Get("/docs2/05|get|"); !ok {
+		t.Error("不匹配前缀的条目不应被删除")
+	}
+	if _, ok := cache.Get("/docs|list|1|0|"); !ok {
+		t.Error("目录自身的条目不应被子路径前缀删除")
+	}
+
+	// 删除后重新写入，索引保持一致
+	cache.Set("/docs/05|get|", 5)
+	cache.DeletePrefix("/docs")
+	if cache.Len() != 0 {
+		t.Errorf("应全部删除，实际剩余 %d", cache.Len())
+	}
+}
+
+// TestCacheConflictCheck 测试上传冲突检查不使用缓存（其他客户端修改后仍能看到变化）
+func TestCacheConflictCheck(t *testing.T) {
+	api, server := newTestClient(t, openlist.WithCache(openlist.NewMemoryCache(time.Minute, 100)))
+	other := openlist.NewOpenListAPI(server.URL, server.Username, server.Password, "")
+	server.AddFile("/docs/a.txt", []byte("old"))
+	server.AddFile("/docs/b.txt", []byte("old"))
+	for _, p := range []string{"/docs/a.txt", "/docs/b.txt"} {
+		if _, err := api.GetFileInfo(p); err != nil {
+			t.Fatalf("获取文件信息失败: %v", err)
+		}
+	}
+
+	// 其他客户端删除文件后，ConflictFail 不应因缓存返回 ErrFileExists
+	if err := other.Remove("/docs", []string{"a.txt"}); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	localPath := writeTempFile(t, "a.txt", "new content")
+	result, err := api.PutFile(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictFail))
+	if err != nil || result.Outcome != openlist.UploadCreated {
+		t.Fatalf("文件已被删除，应新建: %+v, %v", result, err)
+	}
+
+	// 其他客户端修改内容后，ConflictSkip 按最新大小判断
+	server.AddFile("/docs/b.txt", []byte("new content"))
+	result, err = api.PutFile(writeTempFile(t, "b.txt", "new content"), "/docs", openlist.WithConflictPolicy(openlist.ConflictSkip))
+	if err != nil || result.Outcome != openlist.UploadSkipped {
+		t.Fatalf("内容已一致，应跳过: %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+	"github.com/littleboss01/openlistClient/cassette"
+	"github.com/littleboss01/openlistClient/openlisttest"
+)
+
+// TestCassetteRecordReplay 测试录制后离线回放
+func TestCassetteRecordReplay(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	server := openlisttest.NewServer()
+	server.AddFile("/docs/a.txt", []byte("hello"))
+
+	// 录制
+	recorder, err := cassette.New(cassettePath, cassette.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("创建录制器失败: %v", err)
+	}
+	api := openlist.NewOpenListAPI(server.URL, server.Username, server.Password, "", openlist.WithHTTPClient(recorder.Client()))
+	recorded, err := api.ListFiles("/docs", 1, 0, false)
+	if err != nil {
+		t.Fatalf("录制时列目录失败: %v", err)
+	}
+	if err := api.DownloadFile("/docs/a.txt", filepath.Join(t.TempDir(), "a.txt"), nil); err != nil {
+		t.Fatalf("录制时下载失败: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("保存磁带失败: %v", err)
+	}
+	server.Close()
+
+	// 磁带中不包含密码和令牌
+	data, _ := os.ReadFile(cassettePath)
+	if strings.Contains(string(data), `\"password\":\"`+server.Password) || strings.Contains(string(data), "fake-token") ||
+		strings.Contains(string(data), "fake-sign") ||
+		!strings.Contains(string(data), cassette.Redacted) {
+		t.Fatalf("磁带未脱敏:\n%s", data)
+	}
+
+	// 回放（服务已关闭）
+	player, err := cassette.New(cassettePath, cassette.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("加载磁带失败: %v", err)
+	}
+	api = openlist.NewOpenListAPI(server.URL, server.Username, server.Password, "", openlist.WithHTTPClient(player.Client()))
+	replayed, err := api.ListFiles("/docs", 1, 0, false)
+	if err != nil {
+		t.Fatalf("回放时列目录失败: %v", err)
+	}
+	if replayed.Total != recorded.Total || replayed.Content[0].Name != "a.txt" {
+		t.Fatalf("回放结果不一致: %+v", replayed)
+	}
+	localPath := filepath.Join(t.TempDir(), "a.txt")
+	if err := api.DownloadFile("/docs/a.txt", localPath, nil); err != nil {
+		t.Fatalf("回放时下载失败: %v", err)
+	}
+	if content, _ := os.ReadFile(localPath); string(content) != "hello" {
+		t.Fatalf("回放下载内容不正确: %q", content)
+	}
+	if player.Unused() != 0 {
+		t.Fatalf("仍有 %d 个录制交互未被使用", player.Unused())
+	}
+
+	// 未录制的请求直接失败
+	if _, err := api.ListFiles("/other", 1, 0, false); !errors.Is(err, cassette.ErrNoMatch) {
+		t.Fatalf("期望返回 ErrNoMatch，实际: %v", err)
+	}
+}
+
+// TestCassetteRedaction 测试脱敏签名与压缩包密码，并保留大整数精度
+func TestCassetteRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"code":200,"data":{"size":9007199254740993,"sign":"secret-sign",`+
+			`"raw_url":"http://example.com/d/a.zip?sign=secret-sign&x=1"}}`)
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := cassette.New(cassettePath, cassette.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("创建录制器失败: %v", err)
+	}
+	body := strings.NewReader(`{"path":"/a.zip","id":9007199254740993}`)
+	req, _ := http.NewRequest("POST", server.URL+"/ad/a.zip?inner=/b.txt&pass=secret-pass&sign=secret-sign", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := recorder.Client().Do(req)
+	if err != nil {
+		t.Fatalf("录制请求失败: %v", err)
+	}
+	resp.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("保存磁带失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(cassettePath)
+	if strings.Contains(string(data), "secret-sign") || strings.Contains(string(data), "secret-pass") {
+		t.Fatalf("磁带未脱敏签名或压缩包密码:\n%s", data)
+	}
+	if strings.Count(string(data), "9007199254740993") != 2 {
+		t.Fatalf("磁带中的大整数丢失精度:\n%s", data)
+	}
+	if !strings.Contains(string(data), "x=1") {
+		t.Fatalf("磁带应保留非敏感查询参数:\n%s", data)
+	}
+}
@@ -1,83 +1,333 @@
 package test
 
 import (
-	"fmt"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	openlist "github.com/littleboss01/openlistClient"
+	"github.com/littleboss01/openlistClient/openlisttest"
 )
 
-// ClientTest 测试客户端基本功能
+// newTestClient 创建连接到模拟服务的客户端
+func newTestClient(t *testing.T, opts ...openlist.Option) (*openlist.OpenListAPI, *openlisttest.Server) {
+	t.Helper()
+	server := openlisttest.NewServer()
+	t.Cleanup(server.Close)
+	api := openlist.NewOpenListAPI(server.URL, server.Username, server.Password, "", opts...)
+	return api, server
+}
+
+// writeTempFile 创建本地临时文件
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	localPath := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(localPath, []byte(content), 0644); err != nil {
+		t.Fatalf("创建本地文件失败: %v", err)
+	}
+	return localPath
+}
+
+// TestClient 测试客户端基本功能
 func TestClient(t *testing.T) {
-	// 1. 创建客户端实例
-	api := openlist.NewOpenListAPI(
-		"http://localhost:5244", // OpenList服务地址
-		"admin",                 // 用户名
-		"a124149449",            // 密码
-		"http://127.0.0.1:8888", // 代理地址（可选，为空则不使用代理）
-	)
-
-	// 2. 测试代理（可选）
-	if api.TestProxy() {
-		fmt.Println("代理可用")
-	} else {
-		fmt.Println("代理不可用")
-	}
-
-	// 3. 登录（自动触发，也可手动调用api.Login()）
-	_, err := api.Login()
-	if err != nil {
-		fmt.Println("登录失败")
-		return
+	api, server := newTestClient(t)
+	server.AddFile("/r2/roundcubemail-1.4.9.7z", []byte("7z archive"))
+
+	// 1. 登录
+	if _, err := api.Login(); err != nil {
+		t.Fatalf("登录失败: %v", err)
 	}
 
-	// 7. 列出目录
+	// 2. 列出目录
 	listResp, err := api.ListFiles("/", 1, 10, true)
 	if err != nil {
-		fmt.Printf("列出目录失败: %v\n", err)
-		return
+		t.Fatalf("列出目录失败: %v", err)
+	}
+	if listResp.Total != 1 || listResp.Content[0].Name != "r2" {
+		t.Fatalf("目录内容不正确: %+v", listResp)
 	}
-	fmt.Printf("目录总文件数: %d，当前页: %d\n", listResp.Total, listResp.Page)
 
-	// 5. 获取文件信息
+	// 3. 获取文件信息
 	fileInfo, err := api.GetFileInfo("/r2/roundcubemail-1.4.9.7z")
 	if err != nil {
-		fmt.Printf("获取文件信息失败: %v\n", err)
-		return
+		t.Fatalf("获取文件信息失败: %v", err)
+	}
+	if fileInfo.Size != int64(len("7z archive")) || fileInfo.Raw_url == "" {
+		t.Fatalf("文件信息不正确: %+v", fileInfo)
 	}
-	fmt.Printf("文件大小: %d字节，下载地址: %s\n", fileInfo.Size, fileInfo.Raw_url)
 
-	// 6. 搜索文件
+	// 4. 搜索文件
 	results, err := api.SearchFiles("roundcubemail", "/r2")
 	if err != nil {
-		fmt.Printf("文件搜索失败: %v\n", err)
-		return
+		t.Fatalf("文件搜索失败: %v", err)
 	}
-	fmt.Printf("搜索到%d个结果:\n", len(results.Content))
-	for _, res := range results.Content {
-		fmt.Printf("  %s (是否目录: %t)\n", res.Name, res.IsDir)
+	if len(results.Content) != 1 || results.Content[0].FullPath() != "/r2/roundcubemail-1.4.9.7z" {
+		t.Fatalf("搜索结果不正确: %+v", results)
 	}
 
-	// 4. 上传文件
-	remotePath, err := api.UploadFile(
-		"/local/path/test.txt", // 本地文件路径
-		"/remote/docs",         // 远程目录
-	)
+	// 5. 上传文件（自动创建远程目录）
+	remotePath, err := api.UploadFile(writeTempFile(t, "test.txt", "hello"), "/remote/docs")
 	if err != nil {
-		fmt.Printf("文件上传失败: %v\n", err)
-		return
+		t.Fatalf("文件上传失败: %v", err)
+	}
+	if content, ok := server.ReadFile(remotePath); !ok || string(content) != "hello" {
+		t.Fatalf("上传内容不正确: %q", content)
+	}
+
+	// 6. 下载文件
+	localPath := filepath.Join(t.TempDir(), "downloaded.txt")
+	if err := api.DownloadFile(remotePath, localPath, nil, openlist.WithVerifyHash()); err != nil {
+		t.Fatalf("文件下载失败: %v", err)
+	}
+	if content, _ := os.ReadFile(localPath); string(content) != "hello" {
+		t.Fatalf("下载内容不正确: %q", content)
+	}
+
+	// 7. 新建目录、重命名、复制、移动、删除
+	if err := api.Mkdirs("/a/b/c"); err != nil {
+		t.Fatalf("创建多级目录失败: %v", err)
+	}
+	if err := api.Rename("/remote/docs/test.txt", "renamed.txt"); err != nil {
+		t.Fatalf("重命名失败: %v", err)
+	}
+	if err := api.Copy("/remote/docs", "/a/b", []string{"renamed.txt"}); err != nil {
+		t.Fatalf("复制失败: %v", err)
+	}
+	if err := api.Move("/a/b", "/a/b/c", []string{"renamed.txt"}); err != nil {
+		t.Fatalf("移动失败: %v", err)
+	}
+	if err := api.Remove("/remote", []string{"docs"}); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	if !server.Exists("/a/b/c/renamed.txt") || server.Exists("/a/b/renamed.txt") || server.Exists("/remote/docs") {
+		t.Fatal("文件操作结果不正确")
+	}
+}
+
+// TestRenameMoveCopy 测试重命名、移动、复制的请求参数与错误
+func TestRenameMoveCopy(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/src/a.txt", []byte("a"))
+	server.AddFile("/src/b.txt", []byte("b"))
+	server.AddFile("/dst/exists.txt", []byte("x"))
+
+	if err := api.Rename("/src/a.txt", "c.txt"); err != nil {
+		t.Fatalf("重命名失败: %v", err)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/fs/rename"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["path"] != "/src/a.txt" || sent["name"] != "c.txt" {
+		t.Fatalf("重命名请求体不正确: %v", sent)
+	}
+	if err := api.Rename("/src/b.txt", "c.txt"); err == nil {
+		t.Fatal("重命名为已存在的名称时应返回错误")
+	}
+	if err := api.Rename("/src/missing.txt", "d.txt"); err == nil {
+		t.Fatal("重命名不存在的文件时应返回错误")
 	}
-	fmt.Printf("文件上传成功，远程路径: %s\n", remotePath)
-	// 演示使用新的请求参数结构体
 
-	// 演示直接使用通用HTTP请求方法
-	fmt.Println("\n=== 使用通用HTTP请求方法 ===")
+	if err := api.Copy("/src", "/dst", []string{"b.txt", "c.txt"}); err != nil {
+		t.Fatalf("复制失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/fs/copy"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	names, _ := sent["names"].([]any)
+	if sent["src_dir"] != "/src" || sent["dst_dir"] != "/dst" || len(names) != 2 || names[0] != "b.txt" || names[1] != "c.txt" {
+		t.Fatalf("复制请求体不正确: %v", sent)
+	}
 
-	// 使用通用HTTP请求方法获取文件信息
-	fileInfo2, err := api.GetFileInfo("/remote/docs/test.txt")
+	if err := api.Move("/dst", "/moved", []string{"exists.txt"}); err == nil {
+		t.Fatal("移动到不存在的目录时应返回错误")
+	}
+	server.AddDir("/moved")
+	if err := api.Move("/dst", "/moved", []string{"exists.txt"}); err != nil {
+		t.Fatalf("移动失败: %v", err)
+	}
+	if err := api.Move("/src", "/moved", []string{"missing.txt"}); err == nil {
+		t.Fatal("移动不存在的文件时应返回错误")
+	}
+
+	for p, want := range map[string]bool{
+		"/src/b.txt": true, "/src/c.txt": true, "/dst/b.txt": true, "/dst/c.txt": true,
+		"/moved/exists.txt": true, "/dst/exists.txt": false, "/src/a.txt": false,
+	} {
+		if server.Exists(p) != want {
+			t.Errorf("%s 存在状态应为 %v", p, want)
+		}
+	}
+}
+
+// TestUploadConflictPolicy 测试上传冲突策略与秒传哈希
+func TestUploadConflictPolicy(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/docs/a.txt", []byte("old"))
+	localPath := writeTempFile(t, "a.txt", "new content")
+
+	// 已存在时报错
+	_, err := api.PutFile(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictFail))
+	if !errors.Is(err, openlist.ErrFileExists) {
+		t.Fatalf("期望返回 ErrFileExists，实际: %v", err)
+	}
+
+	// 自动重命名
+	result, err := api.PutFile(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictRename), openlist.WithHashHints())
 	if err != nil {
-		fmt.Printf("使用通用HTTP请求方法获取文件信息失败: %v\n", err)
-	} else {
-		fmt.Println("使用通用HTTP请求方法获取文件信息成功\n", fileInfo2)
+		t.Fatalf("上传失败: %v", err)
+	}
+	if result.Outcome != openlist.UploadRenamed || result.Path != "/docs/a (1).txt" || result.Hashes.SHA1() == "" {
+		t.Fatalf("重命名结果不正确: %+v", result)
+	}
+
+	// 内容一致时跳过
+	result, err = api.PutFile(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictRename))
+	if err != nil || result.Path != "/docs/a (2).txt" {
+		t.Fatalf("再次重命名结果不正确: %+v, %v", result, err)
+	}
+	result, err = api.UploadStream(openFile(t, localPath), int64(len("new content")), "/docs/a (1).txt",
+		openlist.WithConflictPolicy(openlist.ConflictSkip))
+	if err != nil || result.Outcome != openlist.UploadSkipped {
+		t.Fatalf("期望跳过上传: %+v, %v", result, err)
+	}
+
+	// 内容不同时覆盖
+	remotePath, err := api.UploadFile(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictSkip), openlist.WithVerifyHash())
+	if err != nil {
+		t.Fatalf("覆盖上传失败: %v", err)
+	}
+	if content, _ := server.ReadFile(remotePath); string(content) != "new content" {
+		t.Fatalf("覆盖后内容不正确: %q", content)
+	}
+
+	// UploadFileWithResult 可区分跳过与实际上传
+	skipped, err := api.UploadFileWithResult(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictSkip))
+	if err != nil || skipped.Outcome != openlist.UploadSkipped || skipped.Path != "/docs/a.txt" {
+		t.Fatalf("期望跳过上传: %+v, %v", skipped, err)
+	}
+	created, err := api.UploadFileWithResult(localPath, "/docs/new", openlist.WithConflictPolicy(openlist.ConflictSkip))
+	if err != nil || created.Outcome != openlist.UploadCreated || created.Size != int64(len("new content")) {
+		t.Fatalf("期望新建文件: %+v, %v", created, err)
+	}
+
+	// 表单上传自动重命名（文件名含空格、括号）
+	renamed, err := api.UploadFileWithResult(localPath, "/docs", openlist.WithConflictPolicy(openlist.ConflictRename), openlist.WithVerifyHash())
+	if err != nil || renamed.Outcome != openlist.UploadRenamed || renamed.Path != "/docs/a (3).txt" {
+		t.Fatalf("表单上传重命名结果不正确: %+v, %v", renamed, err)
+	}
+	if !server.Exists(renamed.Path) {
+		t.Fatalf("重命名后的文件不存在: %s", renamed.Path)
+	}
+	plus, err := api.UploadFileWithResult(writeTempFile(t, "c+d e.txt", "plus"), "/docs")
+	if err != nil || !server.Exists(plus.Path) || plus.Path != "/docs/c+d e.txt" {
+		t.Fatalf("文件名含 + 和空格时上传路径不正确: %+v, %v", plus, err)
+	}
+}
+
+// TestUploadConflictCheckError 测试检查远程文件时的非"不存在"错误不会被当作文件不存在
+func TestUploadConflictCheckError(t *testing.T) {
+	storageMissing := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if op, _ := openlist.OperationFromContext(req.Context()); op.Name == "get" {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"code":500,"message":"storage not found"}`)),
+					Request:    req,
+				}, nil
+			}
+			return next(req)
+		}
+	}
+	api, server := newTestClient(t, openlist.WithMiddleware(storageMissing))
+
+	_, err := api.UploadFile(writeTempFile(t, "a.txt", "x"), "/docs", openlist.WithConflictPolicy(openlist.ConflictFail))
+	var apiErr *openlist.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "storage not found" {
+		t.Fatalf("期望返回存储不存在错误，实际: %v", err)
+	}
+	if server.Exists("/docs/a.txt") {
+		t.Fatal("检查失败时不应继续上传")
+	}
+}
+
+// openFile 打开本地文件（测试结束时关闭）
+func openFile(t *testing.T, localPath string) *os.File {
+	t.Helper()
+	file, err := os.Open(localPath)
+	if err != nil {
+		t.Fatalf("打开本地文件失败: %v", err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return file
+}
+
+// TestFaultInjection 测试模拟服务的故障注入
+func TestFaultInjection(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/docs/big.bin", make([]byte, 64*1024))
+
+	// 5xx
+	server.FailNext(1, 503)
+	if _, err := api.Login(); err == nil {
+		t.Fatal("期望登录因503失败")
+	}
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("故障恢复后列目录失败: %v", err)
+	}
+
+	// 响应体截断
+	server.TruncateDownloads(1024)
+	if err := api.DownloadFile("/docs/big.bin", filepath.Join(t.TempDir(), "big.bin"), nil); err == nil {
+		t.Fatal("期望下载因响应体截断失败")
+	}
+	server.TruncateDownloads(-1)
+
+	// 令牌过期后自动重新登录
+	server.ExpireTokens()
+	logins := server.Calls("/api/auth/login")
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("令牌过期后应自动重新登录: %v", err)
+	}
+	if got := server.Calls("/api/auth/login"); got != logins+1 {
+		t.Errorf("应重新登录1次，实际 %d 次", got-logins)
+	}
+}
+
+// TestDownloadRoutePrefix 测试下载路由只去掉一个前缀（目录名以 p/d 开头时不受影响）
+func TestDownloadRoutePrefix(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/pub/a.txt", []byte("public"))
+	server.AddFile("/data/b.txt", []byte("data"))
+
+	localPath := filepath.Join(t.TempDir(), "a.txt")
+	if err := api.DownloadFile("/pub/a.txt", localPath, nil); err != nil {
+		t.Fatalf("下载 /pub/a.txt 失败: %v", err)
+	}
+	if content, _ := os.ReadFile(localPath); string(content) != "public" {
+		t.Fatalf("下载内容不正确: %q", content)
+	}
+
+	for route, want := range map[string]string{
+		"/d/pub/a.txt":  "public",
+		"/p/pub/a.txt":  "public",
+		"/d/data/b.txt": "data",
+		"/p/data/b.txt": "data",
+	} {
+		resp, err := http.Get(server.URL + route)
+		if err != nil {
+			t.Fatalf("请求 %s 失败: %v", route, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != want {
+			t.Errorf("%s: 状态码 %d，内容 %q", route, resp.StatusCode, body)
+		}
 	}
 }
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestValidateAddition 测试驱动附加配置校验
+func TestValidateAddition(t *testing.T) {
+	info := &openlist.DriverInfo{
+		Config: openlist.DriverConfig{Name: "Local"},
+		Additional: []openlist.DriverItem{
+			{Name: "root_folder_path", Type: "string", Required: true},
+			{Name: "thumbnail", Type: "bool", Default: "false"},
+			{Name: "thumb_concurrency", Type: "number", Default: "16"},
+			{Name: "order_by", Type: "select", Options: "name,size,modified"},
+		},
+	}
+
+	// 合法配置
+	addition := map[string]interface{}{
+		"root_folder_path": "/data",
+		"order_by":         "size",
+	}
+	if err := info.ValidateAddition(addition); err != nil {
+		t.Fatalf("期望校验通过: %v", err)
+	}
+
+	// 填充默认值后类型正确
+	info.ApplyDefaults(addition)
+	if addition["thumbnail"] != false || addition["thumb_concurrency"] != float64(16) {
+		t.Fatalf("默认值填充不正确: %v", addition)
+	}
+	if err := info.ValidateAddition(addition); err != nil {
+		t.Fatalf("填充默认值后期望校验通过: %v", err)
+	}
+
+	// 缺少必填项、类型错误、可选值错误、未知字段
+	err := info.ValidateAddition(map[string]interface{}{
+		"thumbnail":   "yes",
+		"order_by":    "random",
+		"root_folder": "/data",
+	})
+	var validationErr *openlist.AdditionValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("期望返回 AdditionValidationError，实际: %v", err)
+	}
+	if len(validationErr.Issues) != 4 {
+		t.Fatalf("期望4个问题，实际: %v", validationErr.Issues)
+	}
+}
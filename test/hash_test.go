@@ -0,0 +1,166 @@
+package test
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestFileInfoHashes 测试哈希信息解析
+func TestFileInfoHashes(t *testing.T) {
+	// hash_info 字段优先
+	var info openlist.FileInfo
+	body := `{"name":"a.zip","hashinfo":"{\"md5\":\"AAAA\"}","hash_info":{"sha1":"BBBB","gcid":"CCCC"}}`
+	if err := json.Unmarshal([]byte(body), &info); err != nil {
+		t.Fatalf("解析文件信息失败: %v", err)
+	}
+	hashes := info.Hashes()
+	if hashes.SHA1() != "bbbb" || hashes.Get("gcid") != "cccc" || hashes.MD5() != "" {
+		t.Fatalf("hash_info 解析结果不正确: %v", hashes)
+	}
+
+	// hash_info 缺失时回退到 hashinfo 字符串
+	info = openlist.FileInfo{}
+	body = `{"name":"a.zip","hashinfo":"{\"md5\":\"AAAA\"}","hash_info":null}`
+	if err := json.Unmarshal([]byte(body), &info); err != nil {
+		t.Fatalf("解析文件信息失败: %v", err)
+	}
+	if got := info.Hashes().MD5(); got != "aaaa" {
+		t.Fatalf("hashinfo 解析结果不正确: %s", got)
+	}
+
+	// 没有哈希信息
+	info = openlist.FileInfo{HashInfo: "null"}
+	if len(info.Hashes()) != 0 {
+		t.Fatalf("期望没有哈希信息: %v", info.Hashes())
+	}
+}
+
+// TestUploadHashHints 测试秒传哈希请求头
+func TestUploadHashHints(t *testing.T) {
+	api, server := newTestClient(t)
+	content := "hash hint content"
+	localPath := writeTempFile(t, "hint.txt", content)
+	md5Sum := md5.Sum([]byte(content))
+	sha1Sum := sha1.Sum([]byte(content))
+	sha256Sum := sha256.Sum256([]byte(content))
+	want := map[string]string{
+		"X-File-Md5":    hex.EncodeToString(md5Sum[:]),
+		"X-File-Sha1":   hex.EncodeToString(sha1Sum[:]),
+		"X-File-Sha256": hex.EncodeToString(sha256Sum[:]),
+	}
+
+	// 预先计算本地文件哈希
+	result, err := api.PutFile(localPath, "/docs", openlist.WithHashHints())
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	header := server.LastHeader("/api/fs/put")
+	for name, value := range want {
+		if got := header.Get(name); got != value {
+			t.Errorf("请求头 %s 应为 %s，实际为 %s", name, value, got)
+		}
+	}
+	if result.Hashes.MD5() != want["X-File-Md5"] {
+		t.Errorf("上传结果哈希不正确: %v", result.Hashes)
+	}
+	// 计算哈希后应回到起始位置，上传完整内容
+	if uploaded, _ := server.ReadFile("/docs/hint.txt"); string(uploaded) != content {
+		t.Errorf("上传内容不正确: %q", uploaded)
+	}
+
+	// 仅计算指定类型
+	if _, err := api.PutFile(localPath, "/docs/md5", openlist.WithHashHints(openlist.HashMD5)); err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	header = server.LastHeader("/api/fs/put")
+	if header.Get("X-File-Md5") != want["X-File-Md5"] || header.Get("X-File-Sha1") != "" {
+		t.Errorf("只应发送 md5 请求头: %v", header)
+	}
+
+	// 调用方提供的哈希（统一转为小写）
+	_, err = api.UploadStream(strings.NewReader(content), int64(len(content)), "/docs/known.txt",
+		openlist.WithHashes(openlist.HashInfo{openlist.HashSHA1: strings.ToUpper(want["X-File-Sha1"])}))
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	header = server.LastHeader("/api/fs/put")
+	if header.Get("X-File-Sha1") != want["X-File-Sha1"] || header.Get("X-File-Md5") != "" {
+		t.Errorf("应只发送调用方提供的 sha1: %v", header)
+	}
+}
+
+// TestVerifyTransfer 测试传输校验：大小不一致、哈希不一致与服务端没有可比对的哈希
+func TestVerifyTransfer(t *testing.T) {
+	// 服务端不提供哈希时，大小一致也应返回 ErrHashUnavailable，上传结果仍会返回
+	api, server := newTestClient(t)
+	server.SetHashTypes()
+	localPath := writeTempFile(t, "a.txt", "no hash")
+	result, err := api.PutFile(localPath, "/docs", openlist.WithVerifyHash())
+	if !errors.Is(err, openlist.ErrHashUnavailable) {
+		t.Fatalf("期望返回 ErrHashUnavailable，实际: %v", err)
+	}
+	if result == nil || result.Path != "/docs/a.txt" || !server.Exists(result.Path) {
+		t.Fatalf("校验未完成时仍应返回上传结果: %+v", result)
+	}
+	remotePath, err := api.UploadFile(localPath, "/form", openlist.WithVerifyHash())
+	if !errors.Is(err, openlist.ErrHashUnavailable) || remotePath != "/form/a.txt" {
+		t.Fatalf("表单上传期望返回路径和 ErrHashUnavailable，实际: %q, %v", remotePath, err)
+	}
+	err = api.DownloadFile("/docs/a.txt", filepath.Join(t.TempDir(), "a.txt"), nil, openlist.WithVerifyHash())
+	if !errors.Is(err, openlist.ErrHashUnavailable) {
+		t.Fatalf("下载期望返回 ErrHashUnavailable，实际: %v", err)
+	}
+
+	// 只有双方都有的哈希类型参与比对
+	server.SetHashTypes(openlist.HashSHA1)
+	if _, err := api.PutFile(localPath, "/docs", openlist.WithVerifyHash(openlist.HashMD5)); !errors.Is(err, openlist.ErrHashUnavailable) {
+		t.Fatalf("哈希类型无交集时期望返回 ErrHashUnavailable，实际: %v", err)
+	}
+	if _, err := api.PutFile(localPath, "/docs", openlist.WithVerifyHash(openlist.HashMD5, openlist.HashSHA1)); err != nil {
+		t.Fatalf("SHA1 一致时校验应通过: %v", err)
+	}
+
+	// 传输中内容被截断：大小不一致
+	truncate := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/fs/put" {
+				req.Body = io.NopCloser(strings.NewReader("no"))
+				req.ContentLength = 2
+			}
+			return next(req)
+		}
+	}
+	api, _ = newTestClient(t, openlist.WithMiddleware(truncate))
+	var sizeErr *openlist.SizeMismatchError
+	if _, err := api.PutFile(localPath, "/docs", openlist.WithVerifyHash()); !errors.As(err, &sizeErr) ||
+		sizeErr.Expected != 2 || sizeErr.Actual != int64(len("no hash")) {
+		t.Fatalf("期望返回 *SizeMismatchError，实际: %v", err)
+	}
+
+	// 大小一致但内容不同：哈希不一致
+	same := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/fs/put" {
+				req.Body = io.NopCloser(strings.NewReader("NO HASH"))
+			}
+			return next(req)
+		}
+	}
+	api, _ = newTestClient(t, openlist.WithMiddleware(same))
+	var mismatch *openlist.ChecksumMismatchError
+	if _, err := api.PutFile(localPath, "/docs", openlist.WithVerifyHash(openlist.HashSHA256)); !errors.As(err, &mismatch) ||
+		mismatch.Type != openlist.HashSHA256 {
+		t.Fatalf("期望返回 *ChecksumMismatchError，实际: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// countingFS 统计列目录次数的装饰器
+type countingFS struct {
+	openlist.FS
+	lists int
+}
+
+func (c *countingFS) ListFiles(path string, page, perPage int, refresh bool) (*openlist.ListResponse, error) {
+	c.lists++
+	return c.FS.ListFiles(path, page, perPage, refresh)
+}
+
+// TestFSDecorator 测试通过 FS 接口包装客户端
+func TestFSDecorator(t *testing.T) {
+	api, _ := newTestClient(t)
+
+	fs := &countingFS{FS: api}
+	var wrapped openlist.FS = fs
+	if err := wrapped.Mkdirs("/docs/sub"); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if _, err := wrapped.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	if fs.lists != 1 {
+		t.Errorf("列目录次数应为1，实际为 %d", fs.lists)
+	}
+
+	var admin openlist.Admin = api
+	if admin.Storages() == nil || admin.Users() == nil {
+		t.Error("管理接口不应为nil")
+	}
+}
+
+// fakeTasks 模拟任务管理（所有任务立即成功）
+type fakeTasks struct {
+	openlist.TaskManager
+	waited []string
+}
+
+func (f *fakeTasks) WaitTask(ctx context.Context, taskType openlist.TaskType, taskID string) (*openlist.TaskInfo, error) {
+	f.waited = append(f.waited, taskID)
+	return &openlist.TaskInfo{ID: taskID, State: openlist.TaskSucceeded}, nil
+}
+
+// fakeClient 模拟客户端：未覆盖的方法由嵌入的接口提供（调用时 panic）
+type fakeClient struct {
+	openlist.Client
+	tasks *fakeTasks
+}
+
+func (f *fakeClient) Tasks() openlist.TaskManager { return f.tasks }
+
+// 编译期检查模拟实现满足 Client 接口
+var _ openlist.Client = (*fakeClient)(nil)
+
+// waitAll 依赖 Client 接口的调用方代码
+func waitAll(ctx context.Context, client openlist.Client, taskIDs []string) error {
+	for _, id := range taskIDs {
+		if _, err := client.Tasks().WaitTask(ctx, openlist.TaskTypeCopy, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestClientMock 测试以模拟实现替换 Client
+func TestClientMock(t *testing.T) {
+	fake := &fakeClient{tasks: &fakeTasks{}}
+	if err := waitAll(context.Background(), fake, []string{"a", "b"}); err != nil {
+		t.Fatalf("等待任务失败: %v", err)
+	}
+	if len(fake.tasks.waited) != 2 {
+		t.Errorf("应等待2个任务，实际 %v", fake.tasks.waited)
+	}
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestLogger 测试结构化日志记录接口调用与异常
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	api, server := newTestClient(t, openlist.WithLogger(logger))
+
+	if _, err := api.ListFiles("/", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	server.ExpireTokens()
+	if _, err := api.ListFiles("/", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		`"level":"DEBUG","msg":"OpenList 请求","op":"list","path":"/","attempt":1`,
+		`"status":200,"code":200`,
+		`"level":"WARN","msg":"令牌已过期，重新登录"`,
+		`"level":"WARN","msg":"重试请求","op":"list","path":"/","attempt":2`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("日志缺少 %s\n%s", want, output)
+		}
+	}
+
+	// 代理地址中的凭据应被脱敏
+	buf.Reset()
+	openlist.NewOpenListAPI(server.URL, "admin", "admin", "http://user:s3cret@%zz", openlist.WithLogger(logger))
+	if output := buf.String(); !strings.Contains(output, "代理地址解析失败") || strings.Contains(output, "s3cret") {
+		t.Errorf("代理解析失败日志不正确: %s", output)
+	}
+
+}
+
+// countingBody 统计已读取字节数的响应体
+type countingBody struct {
+	io.ReadCloser
+	read *atomic.Int64
+}
+
+func (b countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read.Add(int64(n))
+	return n, err
+}
+
+// TestLoggerDownload 测试下载响应不被预读，且按实际读取字节数记录日志
+func TestLoggerDownload(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// 伪装成未声明长度的JSON响应
+	var read atomic.Int64
+	jsonDownload := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if op, _ := openlist.OperationFromContext(req.Context()); err == nil && op.Name == "download" {
+				resp.Header.Set("Content-Type", "application/json")
+				resp.ContentLength = -1
+				resp.Body = countingBody{ReadCloser: resp.Body, read: &read}
+			}
+			return resp, err
+		}
+	}
+	api, server := newTestClient(t, openlist.WithLogger(logger), openlist.WithMiddleware(jsonDownload))
+	content := bytes.Repeat([]byte(`{"code":200}`), 16*1024)
+	server.AddFile("/docs/data.json", content)
+
+	var firstRead int64 = -1
+	progress := func(downloaded, total int64) {
+		if firstRead < 0 {
+			firstRead = read.Load()
+		}
+	}
+	if err := api.DownloadFile("/docs/data.json", filepath.Join(t.TempDir(), "data.json"), progress); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if firstRead >= int64(len(content)) {
+		t.Errorf("下载响应体不应被预读: 首次回调前已读取 %d 字节", firstRead)
+	}
+	if !strings.Contains(buf.String(), `"op":"download","path":"/docs/data.json"`) {
+		t.Fatalf("日志缺少下载记录:\n%s", buf.String())
+	}
+	if want := fmt.Sprintf(`"bytes_received":%d`, len(content)); !strings.Contains(buf.String(), want) {
+		t.Errorf("日志应记录实际读取的字节数 %s:\n%s", want, buf.String())
+	}
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestMiddleware 测试中间件对接口调用、上传和下载统一生效
+func TestMiddleware(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ops []openlist.Operation
+	)
+	trace := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			op, ok := openlist.OperationFromContext(req.Context())
+			if !ok {
+				t.Errorf("请求缺少操作信息: %s", req.URL)
+			}
+			mu.Lock()
+			ops = append(ops, op)
+			mu.Unlock()
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(req)
+		}
+	}
+	api, server := newTestClient(t, openlist.WithMiddleware(trace))
+
+	remotePath, err := api.UploadFile(writeTempFile(t, "a.txt", "hello"), "/docs")
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if err := api.DownloadFile(remotePath, filepath.Join(t.TempDir(), "a.txt"), nil); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+
+	// 令牌过期后重试的请求应带有递增的尝试次数
+	server.ExpireTokens()
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+
+	want := map[string]bool{"auth.login": false, "upload": false, "download": false, "get": false}
+	retried := false
+	for _, op := range ops {
+		if _, ok := want[op.Name]; ok {
+			want[op.Name] = true
+		}
+		if op.Name == "upload" && op.Path != "/docs/a.txt" {
+			t.Errorf("上传操作路径不正确: %+v", op)
+		}
+		if op.Name == "list" && op.Path == "/docs" && op.Attempt == 2 {
+			retried = true
+		}
+	}
+	for name, seen := range want {
+		if !seen {
+			t.Errorf("中间件未记录操作 %s: %+v", name, ops)
+		}
+	}
+	if !retried {
+		t.Errorf("未记录重试的列目录请求: %+v", ops)
+	}
+}
+
+// TestReloginTransfers 测试令牌过期后上传同样重新登录并重试，无法重放的数据流不重试
+func TestReloginTransfers(t *testing.T) {
+	api, server := newTestClient(t)
+	if _, err := api.Login(); err != nil {
+		t.Fatalf("登录失败: %v", err)
+	}
+
+	// 表单上传
+	server.ExpireTokens()
+	logins := server.Calls("/api/auth/login")
+	if _, err := api.UploadFile(writeTempFile(t, "a.txt", "hello"), "/docs"); err != nil {
+		t.Fatalf("令牌过期后表单上传应重试成功: %v", err)
+	}
+	if got := server.Calls("/api/auth/login") - logins; got != 1 {
+		t.Errorf("应重新登录1次，实际 %d 次", got)
+	}
+
+	// 可回退的数据流
+	server.ExpireTokens()
+	if _, err := api.UploadStream(bytes.NewReader([]byte("seekable")), 8, "/docs/b.txt"); err != nil {
+		t.Fatalf("令牌过期后流式上传应重试成功: %v", err)
+	}
+	if content, _ := server.ReadFile("/docs/b.txt"); string(content) != "seekable" {
+		t.Errorf("重试后上传内容不正确: %q", content)
+	}
+
+	// 无法回退的数据流不重试，返回令牌过期错误
+	server.ExpireTokens()
+	_, err := api.UploadStream(io.LimitReader(strings.NewReader("stream"), 6), 6, "/docs/c.txt")
+	var apiErr *openlist.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+		t.Fatalf("不可重放的上传应返回401错误，实际: %v", err)
+	}
+}
+
+// TestConcurrentLogin 测试并发登录只发送一次登录请求
+func TestConcurrentLogin(t *testing.T) {
+	api, server := newTestClient(t)
+	server.SetLatency(20 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := api.Login(); !ok || err != nil {
+				t.Errorf("登录失败: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := server.Calls("/api/auth/login"); got != 1 {
+		t.Errorf("并发登录应只请求1次，实际 %d 次", got)
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestAddOfflineDownload 测试添加离线下载的请求参数与返回的任务
+func TestAddOfflineDownload(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddDir("/downloads")
+	ctx := context.Background()
+
+	urls := []string{"https://example.com/a.iso", "magnet:?xt=urn:btih:abc"}
+	tasks, err := api.AddOfflineDownload(ctx, urls, "/downloads", openlist.OfflineToolAria2, openlist.DeleteOnUploadSucceed)
+	if err != nil {
+		t.Fatalf("添加离线下载失败: %v", err)
+	}
+
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/fs/add_offline_download"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	sentURLs, _ := sent["urls"].([]any)
+	if len(sentURLs) != 2 || sentURLs[0] != urls[0] || sentURLs[1] != urls[1] ||
+		sent["path"] != "/downloads" || sent["tool"] != "aria2" || sent["delete_policy"] != "delete_on_upload_succeed" {
+		t.Fatalf("请求体不正确: %v", sent)
+	}
+
+	if len(tasks) != 2 || tasks[0].ID == "" || tasks[0].ID == tasks[1].ID || tasks[0].State != openlist.TaskPending {
+		t.Fatalf("返回的任务不正确: %+v", tasks)
+	}
+	// 返回的任务可通过任务接口查询
+	task, err := api.Tasks().Get(ctx, openlist.TaskTypeOfflineDownload, tasks[1].ID)
+	if err != nil || task.Name != tasks[1].Name {
+		t.Fatalf("查询离线下载任务失败: %+v, %v", task, err)
+	}
+
+	// 参数校验
+	if _, err := api.AddOfflineDownload(ctx, nil, "/downloads", openlist.OfflineToolAria2, openlist.DeleteNever); err == nil {
+		t.Error("下载地址为空时应返回错误")
+	}
+	if _, err := api.AddOfflineDownload(ctx, urls, "/missing", openlist.OfflineToolAria2, openlist.DeleteNever); err == nil {
+		t.Error("保存目录不存在时应返回错误")
+	}
+}
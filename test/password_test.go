@@ -0,0 +1,29 @@
+package test
+
+import (
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestStaticPasswords 测试静态密码表按最长前缀匹配
+func TestStaticPasswords(t *testing.T) {
+	passwords := openlist.StaticPasswords{
+		"/private":     "123",
+		"/private/vip": "456",
+	}
+
+	cases := map[string]string{
+		"/private":              "123",
+		"/private/docs/a.txt":   "123",
+		"/private/vip":          "456",
+		"/private/vip/b.txt":    "456",
+		"/private-other/c.txt":  "",
+		"/public/private/d.txt": "",
+	}
+	for path, want := range cases {
+		if got := passwords.Password(path); got != want {
+			t.Errorf("路径 %s 的密码应为 %q，实际为 %q", path, want, got)
+		}
+	}
+}
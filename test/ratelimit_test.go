@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+	"github.com/littleboss01/openlistClient/openlisttest"
+)
+
+// TestRateLimits 测试令牌桶限速、并发上限和按存储覆盖
+func TestRateLimits(t *testing.T) {
+	// 元数据请求限速：令牌桶容量1且几乎不补充，第二次请求需等待的时间超过截止时间时立即失败
+	api, _ := newTestClient(t, openlist.WithRateLimits(openlist.RateLimits{
+		Metadata: openlist.Limit{RPS: 0.001, Burst: 1},
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	search := openlist.SearchRequest{Parent: "/", Keywords: "a"}
+	// 登录消耗唯一的令牌
+	if _, err := api.Search(ctx, search); err == nil || !strings.Contains(err.Error(), "等待限流失败") {
+		t.Fatalf("令牌耗尽后应限流失败，实际: %v", err)
+	}
+
+	// 并发上限：全局最多2个请求同时进行
+	var inFlight, peak atomic.Int64
+	track := func(next openlist.RoundTripFunc) openlist.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			return next(req)
+		}
+	}
+	api, server := newTestClient(t, openlist.WithMiddleware(track), openlist.WithRateLimits(openlist.RateLimits{
+		Global: openlist.Limit{MaxInFlight: 2},
+	}))
+	server.SetLatency(20 * time.Millisecond)
+	if _, err := api.Login(); err != nil {
+		t.Fatalf("登录失败: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := api.ListFiles("/", 1, 0, false); err != nil {
+				t.Errorf("列目录失败: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if peak.Load() > 2 {
+		t.Errorf("并发数应不超过2，实际 %d", peak.Load())
+	}
+
+	// 按存储覆盖：识别到挂载根目录的存储驱动后使用存储的限制
+	api, server = newTestClient(t, openlist.WithRateLimits(openlist.RateLimits{
+		Storages: map[string]openlist.Limit{openlisttest.Provider: {RPS: 0.001, Burst: 1}},
+	}))
+	server.AddDir("/docs/sub")
+	server.AddDir("/other")
+	search = openlist.SearchRequest{Parent: "/docs/sub", Keywords: "a"}
+	for i := 0; i < 2; i++ {
+		if _, err := api.Search(ctx, search); err != nil {
+			t.Fatalf("识别存储驱动前不应限流: %v", err)
+		}
+	}
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	// 第一次消耗令牌，第二次限流失败（同一挂载根目录下的子目录共享存储限制）
+	if _, err := api.Search(ctx, search); err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if _, err := api.Search(ctx, search); err == nil || !strings.Contains(err.Error(), "等待限流失败") {
+		t.Fatalf("存储限速未生效，实际: %v", err)
+	}
+	// 其他挂载根目录不受影响
+	if _, err := api.Search(ctx, openlist.SearchRequest{Parent: "/other", Keywords: "a"}); err != nil {
+		t.Fatalf("其他存储不应限流: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestSearchOptions 测试搜索范围、分页与目录密码
+func TestSearchOptions(t *testing.T) {
+	api, server := newTestClient(t, openlist.WithPasswordProvider(openlist.StaticPasswords{"/private": "123"}))
+	server.AddFile("/private/report-1.txt", []byte("1"))
+	server.AddFile("/private/report-2.txt", []byte("22"))
+	server.AddFile("/private/report-3.txt", []byte("333"))
+	server.AddDir("/private/reports")
+	ctx := context.Background()
+
+	// 1. 仅文件，分页：请求体包含范围、分页，密码来自密码提供者
+	result, err := api.Search(ctx, openlist.SearchRequest{
+		Parent:   "/private",
+		Keywords: "report",
+		Scope:    openlist.SearchScopeFiles,
+		Page:     2,
+		Per_page: 2,
+	})
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/fs/search"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["parent"] != "/private" || sent["keywords"] != "report" || sent["scope"] != float64(openlist.SearchScopeFiles) ||
+		sent["page"] != float64(2) || sent["per_page"] != float64(2) || sent["password"] != "123" {
+		t.Fatalf("搜索请求体不正确: %v", sent)
+	}
+	if result.Total != 3 || len(result.Content) != 1 || result.Content[0].FullPath() != "/private/report-3.txt" {
+		t.Fatalf("仅文件分页搜索结果不正确: %+v", result)
+	}
+
+	// 2. 仅文件夹，显式密码优先于密码提供者
+	result, err = api.Search(ctx, openlist.SearchRequest{
+		Parent:   "/private",
+		Keywords: "report",
+		Scope:    openlist.SearchScopeFolders,
+		Password: "explicit",
+	})
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/fs/search"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["password"] != "explicit" || sent["scope"] != float64(openlist.SearchScopeFolders) {
+		t.Fatalf("搜索请求体不正确: %v", sent)
+	}
+	if result.Total != 1 || result.Content[0].FullPath() != "/private/reports" || !result.Content[0].IsDir {
+		t.Fatalf("仅文件夹搜索结果不正确: %+v", result)
+	}
+
+	// 3. 默认值：父目录 "/"、第1页、每页50条、全部范围
+	result, err = api.Search(ctx, openlist.SearchRequest{Keywords: "report"})
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/fs/search"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["parent"] != "/" || sent["page"] != float64(1) || sent["per_page"] != float64(50) ||
+		sent["scope"] != float64(openlist.SearchScopeAll) || sent["password"] != "" {
+		t.Fatalf("默认搜索请求体不正确: %v", sent)
+	}
+	if result.Total != 4 {
+		t.Fatalf("全部范围搜索结果应为4条, 实际为 %d", result.Total)
+	}
+}
+
+// TestIndexAdmin 测试搜索索引的构建、更新、进度、停止与清空
+func TestIndexAdmin(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/docs/a.txt", []byte("a"))
+	server.AddFile("/docs/sub/b.txt", []byte("b"))
+	server.AddFile("/photos/c.jpg", []byte("c"))
+	ctx := context.Background()
+	index := api.Index()
+
+	// 1. 构建：请求体包含路径与最大深度
+	if err := index.Build(ctx, []string{"/docs"}, 1); err != nil {
+		t.Fatalf("构建索引失败: %v", err)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/admin/index/build"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	paths, _ := sent["paths"].([]any)
+	if len(paths) != 1 || paths[0] != "/docs" || sent["max_depth"] != float64(1) {
+		t.Fatalf("构建索引请求体不正确: %v", sent)
+	}
+	progress, err := index.Progress(ctx)
+	if err != nil {
+		t.Fatalf("获取索引进度失败: %v", err)
+	}
+	// /docs、/docs/a.txt、/docs/sub（深度限制为1，不含 /docs/sub/b.txt）
+	if !progress.IsDone || progress.ObjCount != 3 || progress.LastDoneTime == nil {
+		t.Fatalf("构建后的索引进度不正确: %+v", progress)
+	}
+
+	// 2. 增量更新：路径不能为空
+	if err := index.Update(ctx, nil, -1); err == nil {
+		t.Fatal("更新索引的路径为空时应返回错误")
+	}
+	if server.Calls("/api/admin/index/update") != 0 {
+		t.Fatal("更新索引的路径为空时不应发送请求")
+	}
+	if err := index.Update(ctx, []string{"/photos"}, -1); err != nil {
+		t.Fatalf("更新索引失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/admin/index/update"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	paths, _ = sent["paths"].([]any)
+	if len(paths) != 1 || paths[0] != "/photos" || sent["max_depth"] != float64(-1) {
+		t.Fatalf("更新索引请求体不正确: %v", sent)
+	}
+	if progress, err := index.Progress(ctx); err != nil || progress.ObjCount != 5 {
+		t.Fatalf("更新后的索引进度不正确: %+v, %v", progress, err)
+	}
+
+	// 3. 全量构建：路径为空时索引全部
+	if err := index.Build(ctx, nil, -1); err != nil {
+		t.Fatalf("构建索引失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/admin/index/build"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["paths"] != nil || sent["max_depth"] != float64(-1) {
+		t.Fatalf("全量构建请求体不正确: %v", sent)
+	}
+	if progress := server.IndexProgress(); progress.ObjCount != 6 {
+		t.Fatalf("全量构建后的索引数量应为6, 实际为 %d", progress.ObjCount)
+	}
+
+	// 4. 停止与清空
+	if err := index.Stop(ctx); err != nil {
+		t.Fatalf("停止索引失败: %v", err)
+	}
+	if err := index.Clear(ctx); err != nil {
+		t.Fatalf("清空索引失败: %v", err)
+	}
+	if progress, err := index.Progress(ctx); err != nil || progress.ObjCount != 0 || progress.IsDone || progress.LastDoneTime != nil {
+		t.Fatalf("清空后的索引进度不正确: %+v, %v", progress, err)
+	}
+	if server.Calls("/api/admin/index/stop") != 1 || server.Calls("/api/admin/index/clear") != 1 {
+		t.Fatal("停止和清空索引应各调用一次")
+	}
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestShareManager 测试分享的创建、列出、更新与删除
+func TestShareManager(t *testing.T) {
+	api, server := newTestClient(t)
+	server.AddFile("/docs/a.txt", []byte("a"))
+	server.AddDir("/photos")
+	ctx := context.Background()
+	shares := api.Shares()
+
+	// 1. 创建：请求体包含文件、密码、过期时间等字段
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	created, err := shares.Create(ctx, &openlist.Share{
+		Files:       []string{"/docs/a.txt", "/photos"},
+		Pwd:         "1234",
+		Expires:     &expires,
+		MaxAccessed: 10,
+		Remark:      "给同事",
+	})
+	if err != nil {
+		t.Fatalf("创建分享失败: %v", err)
+	}
+	if created.ID == "" || created.Creator != server.Username {
+		t.Fatalf("创建的分享不正确: %+v", created)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(server.LastBody("/api/share/create"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	files, _ := sent["files"].([]any)
+	if len(files) != 2 || files[0] != "/docs/a.txt" || files[1] != "/photos" || sent["pwd"] != "1234" ||
+		sent["expires"] != "2030-01-02T03:04:05Z" || sent["max_accessed"] != float64(10) || sent["remark"] != "给同事" {
+		t.Fatalf("创建请求体不正确: %v", sent)
+	}
+	if url := shares.URL(created.ID); url != server.URL+"/@s/"+created.ID {
+		t.Fatalf("分享地址不正确: %s", url)
+	}
+
+	// 文件为空时不发送请求，文件不存在时返回错误
+	if _, err := shares.Create(ctx, &openlist.Share{}); err == nil {
+		t.Fatal("分享文件为空时应返回错误")
+	}
+	if server.Calls("/api/share/create") != 1 {
+		t.Fatalf("分享文件为空时不应发送请求, 实际调用 %d 次", server.Calls("/api/share/create"))
+	}
+	if _, err := shares.Create(ctx, &openlist.Share{Files: []string{"/missing"}}); err == nil {
+		t.Fatal("分享文件不存在时应返回错误")
+	}
+
+	second, err := shares.Create(ctx, &openlist.Share{Files: []string{"/photos"}})
+	if err != nil {
+		t.Fatalf("创建分享失败: %v", err)
+	}
+
+	// 2. 列出与获取
+	list, err := shares.List(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("列出分享失败: %v", err)
+	}
+	if list.Total != 2 || len(list.Content) != 2 || list.Content[0].ID != created.ID || list.Content[1].ID != second.ID {
+		t.Fatalf("分享列表不正确: %+v", list)
+	}
+	list, err = shares.List(ctx, 2, 1)
+	if err != nil || list.Total != 2 || len(list.Content) != 1 || list.Content[0].ID != second.ID {
+		t.Fatalf("分页列出分享不正确: %+v, %v", list, err)
+	}
+	got, err := shares.Get(ctx, created.ID)
+	if err != nil || got.Pwd != "1234" || got.Expires == nil || !got.Expires.Equal(expires) {
+		t.Fatalf("获取分享不正确: %+v, %v", got, err)
+	}
+
+	// 3. 更新与启用/禁用
+	got.Pwd = ""
+	got.Expires = nil
+	got.Remark = "公开"
+	updated, err := shares.Update(ctx, got)
+	if err != nil {
+		t.Fatalf("更新分享失败: %v", err)
+	}
+	if err := json.Unmarshal(server.LastBody("/api/share/update"), &sent); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if sent["id"] != created.ID || sent["pwd"] != "" || sent["expires"] != nil || sent["remark"] != "公开" {
+		t.Fatalf("更新请求体不正确: %v", sent)
+	}
+	if updated.Remark != "公开" || updated.Pwd != "" {
+		t.Fatalf("更新后的分享不正确: %+v", updated)
+	}
+	if _, err := shares.Update(ctx, &openlist.Share{ID: "missing", Files: []string{"/photos"}}); err == nil {
+		t.Fatal("更新不存在的分享应返回错误")
+	}
+
+	if err := shares.Disable(ctx, created.ID); err != nil {
+		t.Fatalf("禁用分享失败: %v", err)
+	}
+	if got, _ := shares.Get(ctx, created.ID); got == nil || !got.Disabled {
+		t.Fatalf("分享应已禁用: %+v", got)
+	}
+	if err := shares.Enable(ctx, created.ID); err != nil {
+		t.Fatalf("启用分享失败: %v", err)
+	}
+	if got, _ := shares.Get(ctx, created.ID); got == nil || got.Disabled {
+		t.Fatalf("分享应已启用: %+v", got)
+	}
+
+	// 4. 删除
+	if err := shares.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("删除分享失败: %v", err)
+	}
+	if remaining := server.Shares(); len(remaining) != 1 || remaining[0].ID != second.ID {
+		t.Fatalf("删除后的分享不正确: %+v", remaining)
+	}
+	if _, err := shares.Get(ctx, created.ID); err == nil {
+		t.Fatal("获取已删除的分享应返回错误")
+	}
+	if err := shares.Delete(ctx, created.ID); err == nil {
+		t.Fatal("删除不存在的分享应返回错误")
+	}
+}
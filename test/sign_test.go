@@ -0,0 +1,31 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestSignPath 测试客户端签名与下载链接生成
+func TestSignPath(t *testing.T) {
+	if got := openlist.SignPath("secret", "/docs/a b.txt", time.Time{}); got != "7GOIOKyjp-6OvhGeZWhHgcb7favs5sUQG7IWYEhWPH8=:0" {
+		t.Fatalf("永不过期签名不正确: %s", got)
+	}
+	if got := openlist.SignPath("secret", "/docs/a b.txt", time.Unix(1700000000, 0)); got != "IcLF77CF2ci-DQOGnrYd6jMSxDJHlK_YKTFZZKH07N4=:1700000000" {
+		t.Fatalf("带过期时间签名不正确: %s", got)
+	}
+
+	api := openlist.NewOpenListAPI("http://localhost:5244/", "admin", "123456", "", openlist.WithSignSecret("secret"))
+	link, err := api.SignedDownloadURL("/docs/a b.txt", time.Time{})
+	if err != nil {
+		t.Fatalf("生成签名链接失败: %v", err)
+	}
+	want := "http://localhost:5244/d/docs/a%20b.txt?sign=7GOIOKyjp-6OvhGeZWhHgcb7favs5sUQG7IWYEhWPH8%3D%3A0"
+	if link != want {
+		t.Fatalf("签名链接不正确:\n得到 %s\n期望 %s", link, want)
+	}
+	if got := api.ProxyURL("/docs/a.txt", ""); got != "http://localhost:5244/p/docs/a.txt" {
+		t.Fatalf("代理链接不正确: %s", got)
+	}
+}
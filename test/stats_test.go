@@ -0,0 +1,61 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+	"github.com/littleboss01/openlistClient/openlistprom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestStats 测试客户端统计与 Prometheus 采集器
+func TestStats(t *testing.T) {
+	api, server := newTestClient(t)
+
+	remotePath, err := api.UploadFile(writeTempFile(t, "a.txt", "hello"), "/docs")
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if err := api.DownloadFile(remotePath, filepath.Join(t.TempDir(), "a.txt"), nil); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	server.ExpireTokens()
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+
+	snapshot := api.Stats().Snapshot()
+	if snapshot.BytesDownloaded != int64(len("hello")) || snapshot.BytesUploaded <= int64(len("hello")) {
+		t.Errorf("传输字节数不正确: %+v", snapshot)
+	}
+	if snapshot.ActiveTransfers != 0 || snapshot.Logins != 2 || snapshot.Relogins != 1 {
+		t.Errorf("统计不正确: %+v", snapshot)
+	}
+	counts := map[[2]string]int64{}
+	for _, r := range snapshot.Requests {
+		counts[[2]string{r.Operation, r.Outcome}] = r.Count
+	}
+	if counts[[2]string{"list", openlist.OutcomeAPIError}] != 1 || counts[[2]string{"list", openlist.OutcomeSuccess}] < 1 {
+		t.Errorf("请求统计不正确: %+v", snapshot.Requests)
+	}
+
+	// Prometheus 采集
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(openlistprom.NewCollector(api.Stats(), prometheus.Labels{"instance": "test"}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("采集指标失败: %v", err)
+	}
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if m.GetCounter() != nil {
+				values[family.GetName()] += m.GetCounter().GetValue()
+			}
+		}
+	}
+	if values["openlist_client_relogins_total"] != 1 || values["openlist_client_downloaded_bytes_total"] != float64(len("hello")) {
+		t.Errorf("Prometheus 指标不正确: %v", values)
+	}
+}
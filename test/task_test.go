@@ -0,0 +1,55 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestWaitTask 测试等待任务完成、失败和取消等待
+func TestWaitTask(t *testing.T) {
+	api, server := newTestClient(t, openlist.WithTaskPollInterval(5*time.Millisecond))
+	tasks := api.Tasks()
+
+	// 完成
+	server.AddTask("copy", openlist.TaskInfo{ID: "ok", Name: "copy a"},
+		openlist.TaskPending, openlist.TaskRunning, openlist.TaskSucceeded)
+	task, err := tasks.WaitTask(context.Background(), openlist.TaskTypeCopy, "ok")
+	if err != nil || task.State != openlist.TaskSucceeded {
+		t.Fatalf("任务应成功完成: %+v, %v", task, err)
+	}
+
+	// 失败
+	server.AddTask("copy", openlist.TaskInfo{ID: "bad", Name: "copy b", Error: "disk full"},
+		openlist.TaskRunning, openlist.TaskFailed)
+	task, err = tasks.WaitTask(context.Background(), openlist.TaskTypeCopy, "bad")
+	var failed *openlist.TaskFailedError
+	if !errors.As(err, &failed) || failed.Task.Error != "disk full" || task.State != openlist.TaskFailed {
+		t.Fatalf("期望返回 TaskFailedError，实际: %+v, %v", task, err)
+	}
+
+	// 取消等待（任务一直运行）
+	server.AddTask("copy", openlist.TaskInfo{ID: "slow"}, openlist.TaskRunning)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	task, err = tasks.WaitTask(ctx, openlist.TaskTypeCopy, "slow")
+	if !errors.Is(err, context.DeadlineExceeded) || (task != nil && task.State != openlist.TaskRunning) {
+		t.Fatalf("期望等待超时并返回最后的任务状态，实际: %+v, %v", task, err)
+	}
+
+	// 任务被取消
+	if err := tasks.Cancel(context.Background(), openlist.TaskTypeCopy, "slow"); err != nil {
+		t.Fatalf("取消任务失败: %v", err)
+	}
+	if _, err := tasks.WaitTask(context.Background(), openlist.TaskTypeCopy, "slow"); !errors.As(err, &failed) || failed.Task.State != openlist.TaskCanceled {
+		t.Fatalf("期望任务已取消，实际: %v", err)
+	}
+
+	// 任务不存在
+	if _, err := tasks.WaitTask(context.Background(), openlist.TaskTypeCopy, "missing"); err == nil {
+		t.Fatal("期望任务不存在时返回错误")
+	}
+}
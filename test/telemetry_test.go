@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTelemetry 测试链路追踪span与指标
+func TestTelemetry(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	api, _ := newTestClient(t, openlist.WithTracerProvider(tracerProvider), openlist.WithMeterProvider(meterProvider))
+
+	remotePath, err := api.UploadFile(writeTempFile(t, "a.txt", "hello"), "/docs")
+	if err != nil {
+		t.Fatalf("上传失败: %v", err)
+	}
+	if err := api.DownloadFile(remotePath, filepath.Join(t.TempDir(), "a.txt"), nil); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if _, err := api.ListFiles("/docs", 1, 0, false); err != nil {
+		t.Fatalf("列目录失败: %v", err)
+	}
+	if _, err := api.GetFileInfo("/missing.txt"); err == nil {
+		t.Fatal("期望获取不存在的文件失败")
+	}
+
+	// span
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	for _, name := range []string{"openlist.auth.login", "openlist.upload", "openlist.download", "openlist.list", "openlist.get"} {
+		if _, ok := spans[name]; !ok {
+			t.Errorf("缺少span %s", name)
+		}
+	}
+	if attrs := spanAttrs(spans["openlist.upload"]); attrs["openlist.path"].AsString() != "/docs/a.txt" || attrs["http.response.status_code"].AsInt64() != 200 {
+		t.Errorf("上传span属性不正确: %v", attrs)
+	}
+	if attrs := spanAttrs(spans["openlist.download"]); attrs["openlist.bytes_received"].AsInt64() != int64(len("hello")) {
+		t.Errorf("下载span字节数不正确: %v", attrs)
+	}
+	if attrs := spanAttrs(spans["openlist.list"]); attrs["openlist.provider"].AsString() == "" {
+		t.Errorf("列目录span缺少存储驱动: %v", attrs)
+	}
+	if span := spans["openlist.get"]; span.Status.Code != codes.Error || spanAttrs(span)["openlist.code"].AsInt64() != 500 {
+		t.Errorf("失败请求span状态不正确: %+v", span.Status)
+	}
+
+	// 指标
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("收集指标失败: %v", err)
+	}
+	names := map[string]metricdata.Aggregation{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			names[m.Name] = m.Data
+		}
+	}
+	for _, name := range []string{
+		"openlist.client.request.duration",
+		"openlist.client.request.errors",
+		"openlist.client.transfer.bytes",
+		"openlist.client.transfer.throughput",
+	} {
+		if _, ok := names[name]; !ok {
+			t.Errorf("缺少指标 %s", name)
+		}
+	}
+	if sum, ok := names["openlist.client.request.errors"].(metricdata.Sum[int64]); ok {
+		found := false
+		for _, point := range sum.DataPoints {
+			code, _ := point.Attributes.Value("openlist.error_code")
+			if code.AsString() == "500" && point.Value == 1 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("错误计数不正确: %+v", sum.DataPoints)
+		}
+	}
+}
+
+// spanAttrs 将span属性转换为map
+func spanAttrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"testing"
+
+	openlist "github.com/littleboss01/openlistClient"
+)
+
+// TestPermission 测试权限位组合
+func TestPermission(t *testing.T) {
+	perm := openlist.NewPermission(openlist.PermWrite, openlist.PermRename, openlist.PermWebdavRead)
+	if perm != 1<<3|1<<4|1<<8 {
+		t.Fatalf("权限位计算不正确: %d", perm)
+	}
+	if !perm.Has(openlist.PermWrite, openlist.PermRename) || perm.Has(openlist.PermRemove) {
+		t.Fatalf("权限判断不正确: %s", perm)
+	}
+
+	perm = perm.With(openlist.PermRemove).Without(openlist.PermRename)
+	if got := perm.String(); got != "write|remove|webdav_read" {
+		t.Fatalf("权限名称不正确: %s", got)
+	}
+}
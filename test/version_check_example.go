@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -60,6 +61,18 @@ func VersionCheckExample() {
 
 	fmt.Printf("最新版本: %s, 修改时间: %s\n", latestVersion.Name, latestVersion.Modified)
 
+	// 下载前先查看压缩包内容（服务端解析，无需下载整个压缩包）
+	archivePath := fmt.Sprintf("%s/%s", checkDir, latestVersion.Name)
+	innerList, err := api.ArchiveList(context.Background(), archivePath, "/", "", 1, 0)
+	if err != nil {
+		fmt.Printf("查看压缩包内容失败: %v\n", err)
+	} else {
+		fmt.Printf("压缩包内共 %d 项:\n", innerList.Total)
+		for _, item := range innerList.Content {
+			fmt.Printf("  %s (是否目录: %t)\n", item.Name, item.IsDir)
+		}
+	}
+
 	// 下载最新版本
 	fmt.Printf("开始下载最新版本 %s...\n", latestVersion.Name)
 	localPath := "./" + latestVersion.Name
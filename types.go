@@ -8,16 +8,16 @@ type ProgressFunc func(downloaded, total int64)
 
 // FileInfo 文件信息结构体（对应原Python的Dict返回）
 type FileInfo struct {
-	Name      string      `json:"name"`     // 文件名
-	Size      int64       `json:"size"`     // 文件大小（字节）
-	IsDir     bool        `json:"is_dir"`   // 是否为目录
-	Modified  time.Time   `json:"modified"` // 修改时间
-	Created   time.Time   `json:"created"`
-	Sign      string      `json:"sign"`
-	Thumb     string      `json:"thumb"`
-	Type      int64       `json:"type"`
-	HashInfo  interface{} `json:"hashinfo"`
-	Hash_info interface{} `json:"hash_info"`
+	Name      string    `json:"name"`     // 文件名
+	Size      int64     `json:"size"`     // 文件大小（字节）
+	IsDir     bool      `json:"is_dir"`   // 是否为目录
+	Modified  time.Time `json:"modified"` // 修改时间
+	Created   time.Time `json:"created"`
+	Sign      string    `json:"sign"`
+	Thumb     string    `json:"thumb"`
+	Type      int64     `json:"type"`
+	HashInfo  string    `json:"hashinfo"`  // 哈希信息（服务端序列化后的JSON字符串）
+	Hash_info HashInfo  `json:"hash_info"` // 哈希信息（按哈希类型索引）
 
 	//list没有get才有
 	Raw_url string      `json:"raw_url"`
@@ -26,13 +26,18 @@ type FileInfo struct {
 
 // SearchResult 搜索结果结构体
 type SearchResult struct {
-	Content []struct {
-		Parent string `json:"parent"`
-		Name   string `json:"name"`   // 文件名
-		IsDir  bool   `json:"is_dir"` // 是否为目录
-		Size   int64  `json:"size"`   // 文件大小（字节）
-		Type   int64  `json:"type"`
-	}
+	Content []SearchItem `json:"content"` // 搜索结果列表
+	Total   int          `json:"total"`   // 结果总数
+}
+
+// SearchItem 搜索结果项
+type SearchItem struct {
+	Parent   string    `json:"parent"`   // 所在目录
+	Name     string    `json:"name"`     // 文件名
+	IsDir    bool      `json:"is_dir"`   // 是否为目录
+	Size     int64     `json:"size"`     // 文件大小（字节）
+	Type     int64     `json:"type"`     // 文件类型
+	Modified time.Time `json:"modified"` // 修改时间（部分索引不提供时为零值）
 }
 
 // ListResponse 目录列表响应结构体
@@ -79,14 +84,29 @@ type FileInfoRequest struct {
 
 // SearchRequest 搜索文件请求参数
 type SearchRequest struct {
-	Parent   string `json:"parent"`
-	Keywords string `json:"keywords"`
-	Scope    int    `json:"scope" default:"0"`
+	Parent   string      `json:"parent"`
+	Keywords string      `json:"keywords"`
+	Scope    SearchScope `json:"scope" default:"0"` // 搜索范围
+	Password string      `json:"password"`          // 父目录访问密码
 
 	Page     int `json:"page" default:"1"`
 	Per_page int `json:"per_page" default:"50"`
 }
 
+// IndexRequest 构建/更新搜索索引请求参数
+type IndexRequest struct {
+	Paths    []string `json:"paths"`     // 需要索引的路径（构建时为空表示全部）
+	MaxDepth int      `json:"max_depth"` // 最大深度（-1表示不限制）
+}
+
+// IndexProgress 索引进度
+type IndexProgress struct {
+	ObjCount     uint64     `json:"obj_count"`      // 已索引对象数量
+	IsDone       bool       `json:"is_done"`        // 是否完成
+	LastDoneTime *time.Time `json:"last_done_time"` // 上次完成时间
+	Error        string     `json:"error"`          // 错误信息
+}
+
 // ListRequest 列表请求参数
 type ListRequest struct {
 	Path     string `json:"path"`
@@ -102,6 +122,19 @@ type RemoveRequest struct {
 	Names []string `json:"names"` // 文件名列表
 }
 
+// RenameRequest 重命名请求参数
+type RenameRequest struct {
+	Path string `json:"path"` // 文件或文件夹路径
+	Name string `json:"name"` // 新名称
+}
+
+// MoveCopyRequest 移动/复制请求参数
+type MoveCopyRequest struct {
+	SrcDir string   `json:"src_dir"` // 源目录
+	DstDir string   `json:"dst_dir"` // 目标目录
+	Names  []string `json:"names"`   // 文件名列表
+}
+
 // HTTPRequest 通用HTTP请求配置
 type HTTPRequest struct {
 	Method  string            // HTTP方法 (GET, POST, PUT, DELETE等)
@@ -114,3 +147,240 @@ type HTTPRequest struct {
 type MkdirRequest struct {
 	Path string `json:"path"` // 新目录路径
 }
+
+// OfflineDownloadRequest 添加离线下载请求参数
+type OfflineDownloadRequest struct {
+	URLs         []string `json:"urls"`          // 下载地址列表（HTTP链接、磁力链接、种子地址）
+	Path         string   `json:"path"`          // 保存目录
+	Tool         string   `json:"tool"`          // 下载工具
+	DeletePolicy string   `json:"delete_policy"` // 临时文件删除策略
+}
+
+// OfflineDownloadResponse 添加离线下载响应
+type OfflineDownloadResponse struct {
+	Tasks []TaskInfo `json:"tasks"` // 创建的任务列表
+}
+
+// TaskInfo 后台任务信息
+type TaskInfo struct {
+	ID          string    `json:"id"`           // 任务ID
+	Name        string    `json:"name"`         // 任务名称
+	Creator     string    `json:"creator"`      // 创建者
+	CreatorRole int       `json:"creator_role"` // 创建者角色
+	State       TaskState `json:"state"`        // 任务状态
+	Status      string    `json:"status"`       // 状态描述
+	Progress    float64   `json:"progress"`     // 进度（0-100）
+	StartTime   time.Time `json:"start_time"`   // 开始时间
+	EndTime     time.Time `json:"end_time"`     // 结束时间
+	TotalBytes  int64     `json:"total_bytes"`  // 总字节数
+	Error       string    `json:"error"`        // 错误信息
+}
+
+// ArchiveMetaRequest 获取压缩包元信息请求参数
+type ArchiveMetaRequest struct {
+	Path        string `json:"path"`         // 压缩包路径
+	Password    string `json:"password"`     // 目录访问密码
+	Refresh     bool   `json:"refresh"`      // 是否强制刷新
+	ArchivePass string `json:"archive_pass"` // 压缩包密码
+}
+
+// ArchiveMeta 压缩包元信息
+type ArchiveMeta struct {
+	Comment   string           `json:"comment"`   // 压缩包注释
+	Encrypted bool             `json:"encrypted"` // 是否加密
+	Content   []ArchiveContent `json:"content"`   // 内部文件树（部分格式为空，需使用 ArchiveList）
+	RawURL    string           `json:"raw_url"`   // 压缩包下载地址
+	Sign      string           `json:"sign"`      // 压缩包内文件下载签名
+}
+
+// ArchiveContent 压缩包内文件/目录（树形结构）
+type ArchiveContent struct {
+	Name     string           `json:"name"`     // 文件名
+	Size     int64            `json:"size"`     // 文件大小（字节）
+	IsDir    bool             `json:"is_dir"`   // 是否为目录
+	Modified time.Time        `json:"modified"` // 修改时间
+	Children []ArchiveContent `json:"children"` // 子项（目录时有效）
+}
+
+// ArchiveListRequest 列出压缩包内目录请求参数
+type ArchiveListRequest struct {
+	Path        string `json:"path"`         // 压缩包路径
+	Password    string `json:"password"`     // 目录访问密码
+	Refresh     bool   `json:"refresh"`      // 是否强制刷新
+	Page        int    `json:"page"`         // 页码
+	PerPage     int    `json:"per_page"`     // 每页条数
+	ArchivePass string `json:"archive_pass"` // 压缩包密码
+	InnerPath   string `json:"inner_path"`   // 压缩包内目录（如 "/"）
+}
+
+// DecompressRequest 解压请求参数
+type DecompressRequest struct {
+	SrcDir        string   `json:"src_dir"`          // 压缩包所在目录
+	DstDir        string   `json:"dst_dir"`          // 解压目标目录
+	Name          []string `json:"name"`             // 压缩包文件名列表
+	ArchivePass   string   `json:"archive_pass"`     // 压缩包密码
+	InnerPath     string   `json:"inner_path"`       // 仅解压压缩包内的指定路径（默认 "/"）
+	CacheFull     bool     `json:"cache_full"`       // 是否先完整缓存压缩包
+	PutIntoNewDir bool     `json:"put_into_new_dir"` // 是否解压到以压缩包命名的新目录
+	Overwrite     bool     `json:"overwrite"`        // 是否覆盖已存在的文件
+}
+
+// DecompressResponse 解压响应
+type DecompressResponse struct {
+	Tasks []TaskInfo `json:"task"` // 创建的解压任务
+}
+
+// Storage 存储（挂载）配置
+type Storage struct {
+	ID              uint      `json:"id"`               // 存储ID
+	MountPath       string    `json:"mount_path"`       // 挂载路径
+	Order           int       `json:"order"`            // 排序
+	Driver          string    `json:"driver"`           // 驱动名称（如 "Local"、"115 Cloud"）
+	CacheExpiration int       `json:"cache_expiration"` // 缓存过期时间（分钟）
+	Status          string    `json:"status"`           // 状态（"work" 表示正常）
+	Addition        string    `json:"addition"`         // 驱动附加配置（JSON字符串）
+	Remark          string    `json:"remark"`           // 备注
+	Modified        time.Time `json:"modified"`         // 修改时间
+	Disabled        bool      `json:"disabled"`         // 是否禁用
+	DisableIndex    bool      `json:"disable_index"`    // 是否禁用索引
+	EnableSign      bool      `json:"enable_sign"`      // 是否启用签名
+	OrderBy         string    `json:"order_by"`         // 排序字段
+	OrderDirection  string    `json:"order_direction"`  // 排序方向
+	ExtractFolder   string    `json:"extract_folder"`   // 文件夹置顶/置底
+	WebProxy        bool      `json:"web_proxy"`        // 是否启用Web代理
+	WebdavPolicy    string    `json:"webdav_policy"`    // WebDAV策略
+	ProxyRange      bool      `json:"proxy_range"`      // 代理是否支持Range
+	DownProxyURL    string    `json:"down_proxy_url"`   // 下载代理地址
+}
+
+// StorageListResponse 存储列表响应
+type StorageListResponse struct {
+	Content []Storage `json:"content"` // 存储列表
+	Total   int       `json:"total"`   // 总数量
+}
+
+// CreateStorageResponse 创建存储响应
+type CreateStorageResponse struct {
+	ID uint `json:"id"` // 新建的存储ID
+}
+
+// DriverItem 驱动配置项
+type DriverItem struct {
+	Name     string `json:"name"`     // 配置项名称（addition 中的键）
+	Type     string `json:"type"`     // 类型（string、number、bool、select、text）
+	Default  string `json:"default"`  // 默认值
+	Options  string `json:"options"`  // 可选值（select 类型，逗号分隔）
+	Required bool   `json:"required"` // 是否必填
+	Help     string `json:"help"`     // 帮助信息
+}
+
+// DriverConfig 驱动特性配置
+type DriverConfig struct {
+	Name        string `json:"name"`         // 驱动名称
+	LocalSort   bool   `json:"local_sort"`   // 是否本地排序
+	OnlyProxy   bool   `json:"only_proxy"`   // 是否只能通过代理下载
+	NoCache     bool   `json:"no_cache"`     // 是否不缓存
+	NoUpload    bool   `json:"no_upload"`    // 是否不支持上传
+	NeedMs      bool   `json:"need_ms"`      // 是否需要毫秒级修改时间
+	DefaultRoot string `json:"default_root"` // 默认根目录
+	CheckStatus bool   `json:"check_status"` // 是否检查状态
+	Alert       string `json:"alert"`        // 提示信息
+}
+
+// DriverInfo 驱动信息
+type DriverInfo struct {
+	Common     []DriverItem `json:"common"`     // 通用配置项（存储字段）
+	Additional []DriverItem `json:"additional"` // 驱动附加配置项（addition 字段）
+	Config     DriverConfig `json:"config"`     // 驱动特性配置
+}
+
+// User 用户信息
+type User struct {
+	ID         uint       `json:"id"`         // 用户ID
+	Username   string     `json:"username"`   // 用户名
+	Password   string     `json:"password"`   // 密码（仅创建/修改时填写，更新时为空表示不修改）
+	BasePath   string     `json:"base_path"`  // 基础路径（用户可访问的根目录）
+	Role       UserRole   `json:"role"`       // 角色
+	Disabled   bool       `json:"disabled"`   // 是否禁用
+	Permission Permission `json:"permission"` // 权限位
+	SsoID      string     `json:"sso_id"`     // 单点登录ID
+}
+
+// UserListResponse 用户列表响应
+type UserListResponse struct {
+	Content []User `json:"content"` // 用户列表
+	Total   int    `json:"total"`   // 总数量
+}
+
+// Meta 路径元信息（目录密码、隐藏规则、说明文件、写入规则）
+type Meta struct {
+	ID        uint   `json:"id"`         // 元信息ID
+	Path      string `json:"path"`       // 作用路径
+	Password  string `json:"password"`   // 访问密码
+	PSub      bool   `json:"p_sub"`      // 密码是否应用到子目录
+	Write     bool   `json:"write"`      // 是否允许访客写入
+	WSub      bool   `json:"w_sub"`      // 写入规则是否应用到子目录
+	Hide      string `json:"hide"`       // 隐藏规则（每行一个正则）
+	HSub      bool   `json:"h_sub"`      // 隐藏规则是否应用到子目录
+	Readme    string `json:"readme"`     // 说明（Markdown）
+	RSub      bool   `json:"r_sub"`      // 说明是否应用到子目录
+	Header    string `json:"header"`     // 页头（Markdown）
+	HeaderSub bool   `json:"header_sub"` // 页头是否应用到子目录
+}
+
+// MetaListResponse 元信息列表响应
+type MetaListResponse struct {
+	Content []Meta `json:"content"` // 元信息列表
+	Total   int    `json:"total"`   // 总数量
+}
+
+// SettingItem 站点设置项
+type SettingItem struct {
+	Key     string `json:"key"`     // 设置键
+	Value   string `json:"value"`   // 设置值
+	Help    string `json:"help"`    // 帮助信息
+	Type    string `json:"type"`    // 类型（string、number、bool、select、text）
+	Options string `json:"options"` // 可选值（select 类型，逗号分隔）
+	Group   int    `json:"group"`   // 分组
+	Flag    int    `json:"flag"`    // 标记（0公开、1私有、2只读、3已废弃）
+	Index   int    `json:"index"`   // 排序
+}
+
+// SetAria2Request 设置aria2请求参数
+type SetAria2Request struct {
+	URI    string `json:"uri"`    // aria2 RPC地址
+	Secret string `json:"secret"` // aria2 RPC密钥
+}
+
+// SetQbitRequest 设置qBittorrent请求参数
+type SetQbitRequest struct {
+	URL      string `json:"url"`      // qBittorrent WebUI地址（含账号密码）
+	Seedtime string `json:"seedtime"` // 做种时间（分钟）
+}
+
+// SetTransmissionRequest 设置Transmission请求参数
+type SetTransmissionRequest struct {
+	URI      string `json:"uri"`      // Transmission RPC地址
+	Seedtime string `json:"seedtime"` // 做种时间（分钟）
+}
+
+// Share 分享链接
+type Share struct {
+	ID          string     `json:"id"`           // 分享ID
+	Files       []string   `json:"files"`        // 分享的文件/目录路径
+	Expires     *time.Time `json:"expires"`      // 过期时间（nil表示永不过期）
+	Pwd         string     `json:"pwd"`          // 提取密码
+	Accessed    int        `json:"accessed"`     // 已访问次数
+	MaxAccessed int        `json:"max_accessed"` // 最大访问次数（0表示不限制）
+	Disabled    bool       `json:"disabled"`     // 是否禁用
+	Remark      string     `json:"remark"`       // 备注
+	Readme      string     `json:"readme"`       // 说明（Markdown）
+	Header      string     `json:"header"`       // 页头（Markdown）
+	Creator     string     `json:"creator"`      // 创建者
+}
+
+// ShareListResponse 分享列表响应
+type ShareListResponse struct {
+	Content []Share `json:"content"` // 分享列表
+	Total   int     `json:"total"`   // 总数量
+}
@@ -0,0 +1,262 @@
+package openlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 流式上传时携带的哈希请求头（服务端据此尝试秒传）
+var hashHeaders = map[HashType]string{
+	HashMD5:    "X-File-Md5",
+	HashSHA1:   "X-File-Sha1",
+	HashSHA256: "X-File-Sha256",
+}
+
+// UploadResult 上传结果
+type UploadResult struct {
+	Path    string        // 远程文件完整路径（如 "/docs/test.txt"，重命名时为新路径）
+	Size    int64         // 上传字节数
+	Hashes  HashInfo      // 随请求发送的哈希（用于秒传）
+	Outcome UploadOutcome // 冲突处理结果
+}
+
+// WithHashHints 上传前预先计算本地文件哈希并通过 X-File-* 请求头发送，支持秒传的存储（115、阿里云盘、百度网盘等）可直接完成上传
+// types: 需要计算的哈希类型（为空时计算 md5、sha1、sha256）
+func WithHashHints(types ...HashType) TransferOption {
+	return func(o *transferOptions) {
+		o.hashHints = true
+		o.hintTypes = types
+	}
+}
+
+// WithHashes 使用调用方提供的哈希作为秒传提示（不再读取本地文件计算）
+func WithHashes(hashes HashInfo) TransferOption {
+	return func(o *transferOptions) {
+		o.hashHints = true
+		o.knownHashes = hashes
+	}
+}
+
+// ensureLogin 检查登录状态
+// action: 操作描述（用于错误信息，如 "执行流式上传"）
+func (c *OpenListAPI) ensureLogin(action string) error {
+	if ok, err := c.Login(); !ok {
+		if err != nil {
+			return fmt.Errorf("登录失败: %w", err)
+		}
+		return fmt.Errorf("登录失败，无法%s", action)
+	}
+	return nil
+}
+
+// encodeRemotePath URL编码远程路径（逐段编码，保留斜杠）
+func encodeRemotePath(remotePath string) string {
+	segments := strings.Split(remotePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// joinRemotePath 拼接远程目录与文件名（处理重复斜杠）
+func joinRemotePath(dir, name string) string {
+	return strings.ReplaceAll(fmt.Sprintf("%s/%s", dir, name), "//", "/")
+}
+
+// PutFile 通过流式接口（/api/fs/put）上传本地文件
+// filePath: 本地文件路径
+// remotePath: 远程存储目录（如 "/docs"）
+// opts: 传输选项（可选，如 WithHashHints、WithVerifyHash、WithConflictPolicy）
+// 返回值: 上传结果，错误信息
+func (c *OpenListAPI) PutFile(filePath, remotePath string, opts ...TransferOption) (*UploadResult, error) {
+	// 打开本地文件
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("本地文件不存在: %s", filePath)
+		}
+		return nil, fmt.Errorf("打开本地文件失败: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("获取本地文件信息失败: %w", err)
+	}
+
+	return c.UploadStream(file, stat.Size(), joinRemotePath(remotePath, filepath.Base(filePath)), opts...)
+}
+
+// UploadStream 通过流式接口（/api/fs/put）上传数据流
+// reader: 数据流（使用 WithHashHints 时必须实现 io.Seeker，以便计算哈希后回到起始位置）
+// size: 数据长度（字节）
+// remoteFilePath: 远程文件完整路径（如 "/docs/test.txt"）
+// opts: 传输选项（可选，如 WithHashHints、WithHashes、WithVerifyHash、WithConflictPolicy）
+// 返回值: 上传结果，错误信息
+func (c *OpenListAPI) UploadStream(reader io.Reader, size int64, remoteFilePath string, opts ...TransferOption) (*UploadResult, error) {
+	options := newTransferOptions(opts)
+
+	// 先检查登录状态
+	if err := c.ensureLogin("执行流式上传"); err != nil {
+		return nil, err
+	}
+
+	// 按冲突策略确定上传路径
+	resolution, err := c.resolveConflict(remoteFilePath, size, options, func(types []HashType) (HashInfo, error) {
+		if len(options.knownHashes) > 0 {
+			return options.knownHashes, nil
+		}
+		if _, ok := reader.(io.Seeker); !ok {
+			// 无法读取两次时仅按大小判断
+			return nil, nil
+		}
+		return precomputeHashes(reader, types)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resolution.outcome == UploadSkipped {
+		return &UploadResult{Path: resolution.path, Size: size, Outcome: resolution.outcome}, nil
+	}
+	remoteFilePath = resolution.path
+
+	// 准备秒传哈希
+	hints := HashInfo{}
+	if options.hashHints {
+		for t, v := range options.knownHashes {
+			hints[t] = strings.ToLower(v)
+		}
+		if len(hints) == 0 {
+			computed, err := precomputeHashes(reader, options.hintTypes)
+			if err != nil {
+				return nil, err
+			}
+			hints = computed
+		}
+	}
+
+	// 流式上传不限制总超时（大文件可能耗时较长），由 WithContext 控制
+	client := c.transferClient()
+
+	// 可回退的数据流在令牌过期时可重放
+	seeker, replayable := reader.(io.Seeker)
+	var start int64
+	if replayable {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			replayable = false
+		}
+	}
+
+	// 发送上传请求
+	var hasher *multiHasher
+	err = c.withRelogin(Operation{Name: "upload.stream", Path: remoteFilePath}, replayable, func(op Operation, token string) error {
+		if op.Attempt > 1 {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return fmt.Errorf("重置上传数据流失败: %w", err)
+			}
+		}
+
+		// 需要校验哈希时边上传边计算
+		body := reader
+		if options.verifyHash {
+			hasher = newMultiHasher(options.verifyTypes)
+			body = io.TeeReader(reader, hasher)
+		}
+
+		// 构造HTTP请求（按上传带宽限速）
+		req, err := http.NewRequestWithContext(options.ctx, "PUT", fmt.Sprintf("%s/api/fs/put", c.baseURL), throttle(options.ctx, body, c.uploadBandwidth))
+		if err != nil {
+			return fmt.Errorf("创建上传请求失败: %w", err)
+		}
+		req.ContentLength = size
+		req.Header.Set("Authorization", token)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("File-Path", encodeRemotePath(remoteFilePath))
+		if options.conflictSet {
+			req.Header.Set("Overwrite", fmt.Sprintf("%t", resolution.overwrite))
+		}
+		for t, v := range hints {
+			if header, ok := hashHeaders[t]; ok && v != "" {
+				req.Header.Set(header, v)
+			}
+		}
+
+		resp, err := c.send(client, req, op)
+		if err != nil {
+			return fmt.Errorf("发送上传请求失败: %w", err)
+		}
+		return readUploadResponse(resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.invalidate(remoteFilePath)
+
+	// 校验服务端大小和哈希（校验失败时文件已上传，仍返回上传结果）
+	result := &UploadResult{
+		Path:    remoteFilePath,
+		Size:    size,
+		Hashes:  hints,
+		Outcome: resolution.outcome,
+	}
+	if hasher != nil {
+		remoteInfo, err := c.getFileInfo(remoteFilePath, false)
+		if err != nil {
+			return result, fmt.Errorf("获取上传后文件信息失败: %w", err)
+		}
+		if err := verifyTransfer(remoteFilePath, size, hasher.Sum(), remoteInfo); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// readUploadResponse 读取并检查上传响应（读取后立即关闭，释放并发名额）
+// 业务状态码错误以 *APIError 包装返回，令牌过期时可重新登录后重试
+func readUploadResponse(resp *http.Response) error {
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("读取上传响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusError("上传失败", resp.StatusCode)
+	}
+	var apiResp APIResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return fmt.Errorf("解析上传响应失败，响应体: %s, 原因: %w", string(respBody), err)
+	}
+	if apiResp.Code != 200 {
+		return fmt.Errorf("上传失败: %w", &APIError{Code: apiResp.Code, Message: apiResp.Message})
+	}
+	return nil
+}
+
+// precomputeHashes 预先计算数据流哈希，并将读取位置恢复到起始处
+func precomputeHashes(reader io.Reader, types []HashType) (HashInfo, error) {
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return nil, fmt.Errorf("数据流不支持Seek，无法预先计算哈希，请使用 WithHashes 提供哈希")
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("获取数据流位置失败: %w", err)
+	}
+	hasher := newMultiHasher(types)
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return nil, fmt.Errorf("计算文件哈希失败: %w", err)
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("重置数据流位置失败: %w", err)
+	}
+
+	return hasher.Sum(), nil
+}
@@ -0,0 +1,155 @@
+package openlist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// UserRole 用户角色
+type UserRole int
+
+const (
+	RoleGeneral UserRole = iota // 普通用户
+	RoleGuest                   // 访客
+	RoleAdmin                   // 管理员
+)
+
+// Permission 用户权限位
+// 读取权限由用户的基础路径（BasePath）决定，以下权限位在此基础上附加
+type Permission int32
+
+const (
+	PermSeeHidden             Permission = 1 << iota // 查看隐藏文件
+	PermAccessWithoutPassword                        // 无需密码访问受保护目录
+	PermOfflineDownload                              // 添加离线下载任务
+	PermWrite                                        // 创建目录和上传文件
+	PermRename                                       // 重命名
+	PermMove                                         // 移动
+	PermCopy                                         // 复制
+	PermRemove                                       // 删除
+	PermWebdavRead                                   // WebDAV读取
+	PermWebdavManage                                 // WebDAV管理（写入）
+	PermFTPRead                                      // FTP/SFTP登录与读取
+	PermFTPManage                                    // FTP/SFTP写入
+	PermReadArchive                                  // 读取压缩包内容
+	PermDecompress                                   // 服务端解压
+)
+
+// permissionNames 权限位名称（按位序）
+var permissionNames = []string{
+	"see_hidden", "access_without_password", "offline_download", "write",
+	"rename", "move", "copy", "remove", "webdav_read", "webdav_manage",
+	"ftp_read", "ftp_manage", "read_archive", "decompress",
+}
+
+// NewPermission 组合多个权限位
+// 例: NewPermission(PermWrite, PermRename, PermWebdavRead)
+func NewPermission(perms ...Permission) Permission {
+	var p Permission
+	for _, perm := range perms {
+		p |= perm
+	}
+	return p
+}
+
+// With 添加权限位
+func (p Permission) With(perms ...Permission) Permission {
+	return p | NewPermission(perms...)
+}
+
+// Without 移除权限位
+func (p Permission) Without(perms ...Permission) Permission {
+	return p &^ NewPermission(perms...)
+}
+
+// Has 是否拥有全部指定权限位
+func (p Permission) Has(perms ...Permission) bool {
+	need := NewPermission(perms...)
+	return p&need == need
+}
+
+// String 实现fmt.Stringer接口（如 "write|rename"）
+func (p Permission) String() string {
+	var names []string
+	for i, name := range permissionNames {
+		if p&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// UserService 用户管理，对应 /api/admin/user/*
+type UserService struct {
+	api *OpenListAPI
+}
+
+// Users 获取用户管理客户端（需要管理员权限）
+func (c *OpenListAPI) Users() UserAdmin {
+	return &UserService{api: c}
+}
+
+// List 列出用户
+// page: 页码（默认 1）
+// perPage: 每页条数（0表示不分页）
+func (s *UserService) List(ctx context.Context, page, perPage int) (*UserListResponse, error) {
+	listResp := &UserListResponse{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/user/list", pageQuery(page, perPage), nil, listResp); err != nil {
+		return nil, fmt.Errorf("列出用户失败: %w", err)
+	}
+	return listResp, nil
+}
+
+// Get 获取单个用户
+func (s *UserService) Get(ctx context.Context, id uint) (*User, error) {
+	user := &User{}
+	if err := s.api.adminRequest(ctx, "GET", "/api/admin/user/get", idQuery(id), nil, user); err != nil {
+		return nil, fmt.Errorf("获取用户失败: %w", err)
+	}
+	return user, nil
+}
+
+// Create 创建用户
+func (s *UserService) Create(ctx context.Context, user *User) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/user/create", nil, user, nil); err != nil {
+		return fmt.Errorf("创建用户失败: %w", err)
+	}
+	return nil
+}
+
+// Update 更新用户（user.ID 必须有效，Password 为空时不修改密码）
+func (s *UserService) Update(ctx context.Context, user *User) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/user/update", nil, user, nil); err != nil {
+		return fmt.Errorf("更新用户失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除用户
+func (s *UserService) Delete(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/user/delete", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("删除用户失败: %w", err)
+	}
+	return nil
+}
+
+// Cancel2FA 取消用户的两步验证
+func (s *UserService) Cancel2FA(ctx context.Context, id uint) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/user/cancel_2fa", idQuery(id), nil, nil); err != nil {
+		return fmt.Errorf("取消两步验证失败: %w", err)
+	}
+	return nil
+}
+
+// DelCache 删除用户缓存
+func (s *UserService) DelCache(ctx context.Context, username string) error {
+	if err := s.api.adminRequest(ctx, "POST", "/api/admin/user/del_cache", url.Values{"username": {username}}, nil, nil); err != nil {
+		return fmt.Errorf("删除用户缓存失败: %w", err)
+	}
+	return nil
+}